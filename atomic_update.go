@@ -0,0 +1,132 @@
+package solr
+
+import "encoding/json"
+
+// List of atomic update operations
+//
+// Refer to https://solr.apache.org/guide/8_8/updating-parts-of-documents.html#atomic-updates
+const (
+	opSet         = "set"
+	opAdd         = "add"
+	opAddDistinct = "add-distinct"
+	opRemove      = "remove"
+	opRemoveRegex = "removeregex"
+	opInc         = "inc"
+)
+
+// AtomicUpdate is an atomic (partial) update builder for a single document
+type AtomicUpdate struct {
+	// uniqueKey is the unique key field of the document, defaults to "id"
+	uniqueKey string
+	// id is the unique key value of the document to update
+	id string
+	// root is the _root_ of a child document
+	root string
+	// version is the optional _version_ constraint
+	version int64
+	// ops is the field to operation-value map
+	ops map[string]M
+}
+
+var _ json.Marshaler = (*AtomicUpdate)(nil)
+
+// NewAtomicUpdate takes the unique key value of the
+// document to update and returns a new AtomicUpdate
+func NewAtomicUpdate(id string) *AtomicUpdate {
+	return &AtomicUpdate{
+		uniqueKey: "id",
+		id:        id,
+		ops:       map[string]M{},
+	}
+}
+
+// UniqueKey overrides the unique key field name (default "id")
+func (u *AtomicUpdate) UniqueKey(uniqueKey string) *AtomicUpdate {
+	u.uniqueKey = uniqueKey
+	return u
+}
+
+// Root sets the _root_ field which is required when updating a child document.
+//
+// Refer to https://solr.apache.org/guide/8_8/updating-parts-of-documents.html#updating-child-documents
+func (u *AtomicUpdate) Root(root string) *AtomicUpdate {
+	u.root = root
+	return u
+}
+
+// Version sets the _version_ constraint for optimistic concurrency.
+//
+// Refer to https://solr.apache.org/guide/8_8/updating-parts-of-documents.html#optimistic-concurrency
+func (u *AtomicUpdate) Version(version int64) *AtomicUpdate {
+	u.version = version
+	return u
+}
+
+// Set sets or replaces the field value(s) with the specified value(s),
+// or removes the values if nil is specified as the new value.
+func (u *AtomicUpdate) Set(field string, value interface{}) *AtomicUpdate {
+	return u.addOp(field, opSet, value)
+}
+
+// Add adds the specified values to a multiValued field. Child documents
+// can be added to a nested field by passing a document or a list of documents.
+func (u *AtomicUpdate) Add(field string, value interface{}) *AtomicUpdate {
+	return u.addOp(field, opAdd, value)
+}
+
+// AddDistinct adds the specified values to a multiValued field,
+// only if not already present.
+func (u *AtomicUpdate) AddDistinct(field string, value interface{}) *AtomicUpdate {
+	return u.addOp(field, opAddDistinct, value)
+}
+
+// Remove removes all occurrences of the specified values from a multiValued field.
+// Child documents can be removed from a nested field by specifying their ids.
+func (u *AtomicUpdate) Remove(field string, value interface{}) *AtomicUpdate {
+	return u.addOp(field, opRemove, value)
+}
+
+// RemoveRegex removes all occurrences of the values matching
+// the specified regex from a multiValued field.
+func (u *AtomicUpdate) RemoveRegex(field string, regex interface{}) *AtomicUpdate {
+	return u.addOp(field, opRemoveRegex, regex)
+}
+
+// Inc increments a numeric value by a specific amount
+func (u *AtomicUpdate) Inc(field string, value interface{}) *AtomicUpdate {
+	return u.addOp(field, opInc, value)
+}
+
+func (u *AtomicUpdate) addOp(field, op string, value interface{}) *AtomicUpdate {
+	if _, ok := u.ops[field]; !ok {
+		u.ops[field] = M{}
+	}
+
+	u.ops[field][op] = value
+	return u
+}
+
+// BuildDocument builds the atomic update document
+func (u *AtomicUpdate) BuildDocument() M {
+	m := M{u.uniqueKey: u.id}
+
+	if u.root != "" {
+		m["_root_"] = u.root
+	}
+
+	if u.version != 0 {
+		m["_version_"] = u.version
+	}
+
+	for field, op := range u.ops {
+		m[field] = op
+	}
+
+	return m
+}
+
+// MarshalJSON implements json.Marshaler so that atomic updates
+// can be encoded directly into the body of an update request.
+func (u *AtomicUpdate) MarshalJSON() ([]byte, error) {
+	return json.Marshal(u.BuildDocument())
+}
@@ -0,0 +1,64 @@
+package solr_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/stevenferrer/solr-go"
+)
+
+func TestAtomicUpdate(t *testing.T) {
+	t.Run("operations", func(t *testing.T) {
+		got := solr.NewAtomicUpdate("mydoc").
+			Version(1234).
+			Set("price", 100).
+			Add("tags", []string{"new", "sale"}).
+			AddDistinct("colors", "red").
+			Remove("sizes", "XL").
+			RemoveRegex("sizes", "^X+").
+			Inc("popularity", 20).
+			BuildDocument()
+
+		expect := solr.M{
+			"id":         "mydoc",
+			"_version_":  int64(1234),
+			"price":      solr.M{"set": 100},
+			"tags":       solr.M{"add": []string{"new", "sale"}},
+			"colors":     solr.M{"add-distinct": "red"},
+			"sizes":      solr.M{"remove": "XL", "removeregex": "^X+"},
+			"popularity": solr.M{"inc": 20},
+		}
+		assert.Equal(t, expect, got)
+	})
+
+	t.Run("child document", func(t *testing.T) {
+		got := solr.NewAtomicUpdate("P11!S21").
+			UniqueKey("sku").
+			Root("P11!prod").
+			Inc("price_i", 73).
+			BuildDocument()
+
+		expect := solr.M{
+			"sku":     "P11!S21",
+			"_root_":  "P11!prod",
+			"price_i": solr.M{"inc": 73},
+		}
+		assert.Equal(t, expect, got)
+	})
+
+	t.Run("marshal json", func(t *testing.T) {
+		updates := []*solr.AtomicUpdate{
+			solr.NewAtomicUpdate("P11!prod").
+				Add("skus", solr.M{"id": "P11!S31", "color_s": "brown"}),
+		}
+
+		b, err := json.Marshal(updates)
+		require.NoError(t, err)
+
+		expect := `[{"id":"P11!prod","skus":{"add":{"color_s":"brown","id":"P11!S31"}}}]`
+		assert.Equal(t, expect, string(b))
+	})
+}
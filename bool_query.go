@@ -0,0 +1,82 @@
+package solr
+
+// BoolQuery is the bool query of the JSON query DSL, which combines
+// queries e.g. a knn query and a lexical query for hybrid search.
+// Each clause is either a query string e.g. from a query parser's
+// BuildParser, a DSL object e.g. from KNNQueryParser.BuildDSL,
+// or a nested BoolQuery.
+//
+// Refer to https://solr.apache.org/guide/8_8/json-query-dsl.html#nested-queries
+type BoolQuery struct {
+	must    []interface{}
+	should  []interface{}
+	mustNot []interface{}
+	filter  []interface{}
+}
+
+// NewBoolQuery returns a new BoolQuery
+func NewBoolQuery() *BoolQuery {
+	return &BoolQuery{}
+}
+
+// Must adds the clauses that must match and contribute to the score
+func (bq *BoolQuery) Must(clauses ...interface{}) *BoolQuery {
+	bq.must = append(bq.must, clauses...)
+	return bq
+}
+
+// Should adds the clauses that should match and contribute to the score
+func (bq *BoolQuery) Should(clauses ...interface{}) *BoolQuery {
+	bq.should = append(bq.should, clauses...)
+	return bq
+}
+
+// MustNot adds the clauses that must not match
+func (bq *BoolQuery) MustNot(clauses ...interface{}) *BoolQuery {
+	bq.mustNot = append(bq.mustNot, clauses...)
+	return bq
+}
+
+// Filter adds the clauses that must match without contributing to the score
+func (bq *BoolQuery) Filter(clauses ...interface{}) *BoolQuery {
+	bq.filter = append(bq.filter, clauses...)
+	return bq
+}
+
+// BuildDSL builds the bool query
+func (bq *BoolQuery) BuildDSL() M {
+	m := M{}
+
+	if len(bq.must) > 0 {
+		m["must"] = buildClauses(bq.must)
+	}
+
+	if len(bq.should) > 0 {
+		m["should"] = buildClauses(bq.should)
+	}
+
+	if len(bq.mustNot) > 0 {
+		m["must_not"] = buildClauses(bq.mustNot)
+	}
+
+	if len(bq.filter) > 0 {
+		m["filter"] = buildClauses(bq.filter)
+	}
+
+	return M{"bool": m}
+}
+
+// buildClauses builds the nested bool queries in the clauses
+func buildClauses(clauses []interface{}) []interface{} {
+	built := make([]interface{}, 0, len(clauses))
+	for _, clause := range clauses {
+		if bq, ok := clause.(*BoolQuery); ok {
+			built = append(built, bq.BuildDSL())
+			continue
+		}
+
+		built = append(built, clause)
+	}
+
+	return built
+}
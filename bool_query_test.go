@@ -0,0 +1,48 @@
+package solr_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/stevenferrer/solr-go"
+)
+
+func TestBoolQuery(t *testing.T) {
+	got := solr.NewBoolQuery().BuildDSL()
+	assert.Equal(t, solr.M{"bool": solr.M{}}, got)
+
+	knn := solr.NewKNNQueryParser("vector", solr.Vector{1, 2, 3}).
+		TopK(10).
+		PreFilters("inStock:true")
+	lexical := solr.NewExtendedDisMaxQueryParser().Qf("name").Query("ipod").BuildParser()
+
+	got = solr.NewBoolQuery().
+		Should(knn.BuildDSL(), lexical).
+		Filter("inStock:true").
+		MustNot(solr.NewBoolQuery().Must("cat:refurbished")).
+		BuildDSL()
+
+	expect := solr.M{
+		"bool": solr.M{
+			"should": []interface{}{
+				solr.M{"knn": solr.M{
+					"f":         "vector",
+					"topK":      10,
+					"preFilter": []string{"inStock:true"},
+					"query":     "[1,2,3]",
+				}},
+				"{!edismax qf=name v=ipod}",
+			},
+			"filter": []interface{}{"inStock:true"},
+			"must_not": []interface{}{
+				solr.M{"bool": solr.M{"must": []interface{}{"cat:refurbished"}}},
+			},
+		},
+	}
+	assert.Equal(t, expect, got)
+
+	query := solr.NewDSLQuery(got).Limit(10).BuildQuery()
+	assert.Equal(t, got, query["query"])
+	assert.Equal(t, 10, query["limit"])
+}
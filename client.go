@@ -55,16 +55,87 @@ type Client interface {
 	//
 	// Refer to https://solr.apache.org/guide/8_8/json-request-api.html
 	Query(ctx context.Context, collection string, query *Query) (*QueryResponse, error)
+	// QueryWithCollation sends the query and, if it has no results, re-runs it
+	// with the best spellcheck collation in place of the main query. The query
+	// should enable spellcheck with collate. It returns the collation that was
+	// used, which is empty if the query was not re-run.
+	//
+	// Refer to https://solr.apache.org/guide/8_8/spell-checking.html#spellcheck-collate
+	QueryWithCollation(ctx context.Context, collection string, query *Query) (*QueryResponse, string, error)
+	// QueryStream is the same as Query but decodes the response as it is read,
+	// calling fn for each document instead of collecting them into the response.
+	//
+	// Refer to https://solr.apache.org/guide/8_8/json-request-api.html
+	QueryStream(ctx context.Context, collection string, query *Query, fn func(doc M) error) (*QueryResponse, error)
 
 	// Update can be used to add, update, or delete a document from the index.
 	//
 	// Refer to https://solr.apache.org/guide/8_8/uploading-data-with-index-handlers.html
 	Update(ctx context.Context, collection string, ct MimeType, body io.Reader) (*UpdateResponse, error)
-	// Commit commits the last update
-	Commit(ctx context.Context, collection string) error
+	// UpdateWithParams is the same as Update but accepts additional update params.
+	//
+	// Refer to https://solr.apache.org/guide/8_8/uploading-data-with-index-handlers.html
+	UpdateWithParams(ctx context.Context, collection string, ct MimeType, body io.Reader, params *UpdateParams) (*UpdateResponse, error)
+	// UpdateWithRetry does a safe read-modify-write of a document. It fetches the
+	// latest version of the document via real-time get, applies fn and sends the
+	// result with a _version_ constraint, retrying with backoff when the update
+	// conflicts with a concurrent write. fn receives nil if the document does not exist yet,
+	// and must not return a nil document.
+	//
+	// Refer to https://solr.apache.org/guide/8_8/updating-parts-of-documents.html#optimistic-concurrency
+	UpdateWithRetry(ctx context.Context, collection, id string, fn func(doc M) (M, error)) (*UpdateResponse, error)
+	// Get fetches the latest version of the documents from the real-time get handler.
+	//
+	// Refer to https://solr.apache.org/guide/8_8/realtime-get.html
+	Get(ctx context.Context, collection string, ids ...string) (*GetResponse, error)
+	// GetWithParams is the same as Get but accepts additional params.
+	//
+	// Refer to https://solr.apache.org/guide/8_8/realtime-get.html
+	GetWithParams(ctx context.Context, collection string, params *RealTimeGetParams) (*GetResponse, error)
+	// Commit commits the last update. If opts is nil, a hard commit with
+	// the default options is issued.
+	//
+	// Refer to https://solr.apache.org/guide/8_8/uploading-data-with-index-handlers.html#commit-and-optimize-during-updates
+	Commit(ctx context.Context, collection string, opts *CommitOptions) (*UpdateResponse, error)
 
 	// Schema API
 
+	// GetSchema returns the whole schema.
+	//
+	// Refer to https://solr.apache.org/guide/8_8/schema-api.html#retrieve-the-entire-schema
+	GetSchema(ctx context.Context, collection string) (*Schema, error)
+	// ListFields returns the fields.
+	//
+	// Refer to https://solr.apache.org/guide/8_8/schema-api.html#list-fields
+	ListFields(ctx context.Context, collection string, includeDynamic, showDefaults bool) ([]Field, error)
+	// GetField returns the field.
+	//
+	// Refer to https://solr.apache.org/guide/8_8/schema-api.html#list-fields
+	GetField(ctx context.Context, collection, name string, showDefaults bool) (*Field, error)
+	// ListDynamicFields returns the dynamic field rules.
+	//
+	// Refer to https://solr.apache.org/guide/8_8/schema-api.html#list-dynamic-fields
+	ListDynamicFields(ctx context.Context, collection string, showDefaults bool) ([]Field, error)
+	// ListFieldTypes returns the field types.
+	//
+	// Refer to https://solr.apache.org/guide/8_8/schema-api.html#list-field-types
+	ListFieldTypes(ctx context.Context, collection string, showDefaults bool) ([]FieldType, error)
+	// ListCopyFields returns the copy field rules.
+	//
+	// Refer to https://solr.apache.org/guide/8_8/schema-api.html#list-copy-fields
+	ListCopyFields(ctx context.Context, collection string) ([]CopyField, error)
+	// GetUniqueKey returns the unique key field name.
+	//
+	// Refer to https://solr.apache.org/guide/8_8/schema-api.html#show-unique-key
+	GetUniqueKey(ctx context.Context, collection string) (string, error)
+	// GetSchemaVersion returns the schema version.
+	//
+	// Refer to https://solr.apache.org/guide/8_8/schema-api.html#show-the-schema-version
+	GetSchemaVersion(ctx context.Context, collection string) (float64, error)
+	// GetSimilarity returns the global similarity.
+	//
+	// Refer to https://solr.apache.org/guide/8_8/schema-api.html#show-global-similarity
+	GetSimilarity(ctx context.Context, collection string) (*Similarity, error)
 	// AddFields adds new field definitions to the schema.
 	//
 	// Refer to https://solr.apache.org/guide/8_8/schema-api.html#add-a-new-field
@@ -109,6 +180,56 @@ type Client interface {
 	//
 	// Refer to https://solr.apache.org/guide/8_8/schema-api.html#delete-a-copy-field-rule
 	DeleteCopyFields(ctx context.Context, collection string, copyFields ...CopyField) error
+	// Plan diffs the live schema of the collection with the desired schema and
+	// returns the ordered changes to apply. The field types, fields, dynamic fields
+	// and copy fields which are not in the desired schema are only deleted if
+	// opts.Prune is true, opts can be nil. Changes that can lose data on a
+	// populated index e.g. deleting a field are flagged as destructive.
+	//
+	// Refer to https://solr.apache.org/guide/8_8/schema-api.html#modify-the-schema
+	Plan(ctx context.Context, collection string, desired Schema, opts *PlanOptions) (*SchemaPlan, error)
+	// Apply sends the commands of the plan in a single schema request. It returns
+	// ErrDestructiveChanges if the plan has destructive changes and allowDestructive is false.
+	//
+	// Refer to https://solr.apache.org/guide/8_8/schema-api.html#modify-the-schema
+	Apply(ctx context.Context, collection string, plan *SchemaPlan, allowDestructive bool) error
+	// ModifySchema sends the commands of the batch in a single request which Solr
+	// applies atomically. If a command fails, none of the commands are applied and
+	// the returned *ResponseError has the error messages of each failed command.
+	//
+	// Refer to https://solr.apache.org/guide/8_8/schema-api.html#multiple-commands-in-a-single-post
+	ModifySchema(ctx context.Context, collection string, batch *SchemaBatch) error
+
+	// Learning To Rank API
+
+	// UploadFeatures uploads the features to the feature store.
+	//
+	// Refer to https://solr.apache.org/guide/8_8/learning-to-rank.html#uploading-features
+	UploadFeatures(ctx context.Context, collection string, features ...LTRFeature) error
+	// ListFeatureStores returns the names of the feature stores.
+	//
+	// Refer to https://solr.apache.org/guide/8_8/learning-to-rank.html#uploading-features
+	ListFeatureStores(ctx context.Context, collection string) ([]string, error)
+	// ListFeatures returns the features of the feature store.
+	//
+	// Refer to https://solr.apache.org/guide/8_8/learning-to-rank.html#uploading-features
+	ListFeatures(ctx context.Context, collection, store string) ([]LTRFeature, error)
+	// DeleteFeatureStore deletes the feature store and all of its features.
+	//
+	// Refer to https://solr.apache.org/guide/8_8/learning-to-rank.html#uploading-features
+	DeleteFeatureStore(ctx context.Context, collection, store string) error
+	// UploadModels uploads the models to the model store.
+	//
+	// Refer to https://solr.apache.org/guide/8_8/learning-to-rank.html#uploading-a-model
+	UploadModels(ctx context.Context, collection string, models ...LTRModel) error
+	// ListModels returns the models of the model store.
+	//
+	// Refer to https://solr.apache.org/guide/8_8/learning-to-rank.html#uploading-a-model
+	ListModels(ctx context.Context, collection string) ([]LTRModel, error)
+	// DeleteModel deletes the model from the model store.
+	//
+	// Refer to https://solr.apache.org/guide/8_8/learning-to-rank.html#uploading-a-model
+	DeleteModel(ctx context.Context, collection, model string) error
 
 	// Config API
 
@@ -133,6 +254,40 @@ type Client interface {
 	// Refer to https://solr.apache.org/guide/8_8/config-api.html#commands-for-handlers-and-components
 	DeleteComponents(ctx context.Context, collection string, component ...*Component) error
 
+	// MoreLikeThis finds documents similar to a document or
+	// to a text (stream body) via the more like this handler.
+	//
+	// Refer to https://solr.apache.org/guide/8_8/morelikethis.html#using-the-morelikethishandler
+	MoreLikeThis(ctx context.Context, collection string, params *MoreLikeThisParams) (*MoreLikeThisResponse, error)
+
+	// Streaming Expressions API
+
+	// StreamTuples sends the streaming expression to the stream handler and
+	// returns the iterator over the result tuples, which must be closed if it
+	// is not iterated until the end.
+	//
+	// Refer to https://solr.apache.org/guide/8_8/streaming-expressions.html#streaming-requests-and-responses
+	StreamTuples(ctx context.Context, collection string, expr *StreamExpr) (*TupleStream, error)
+
+	// Parallel SQL Interface
+
+	// SQL sends the SQL statement to the parallel SQL interface and returns the
+	// iterator over the result tuples, which must be closed if it is not iterated
+	// until the end. opts is optional.
+	//
+	// Refer to https://solr.apache.org/guide/8_8/parallel-sql-interface.html
+	SQL(ctx context.Context, collection, stmt string, opts *SQLOptions) (*TupleStream, error)
+
+	// Export API
+
+	// Export streams all the documents matching the query from the export
+	// handler. It returns the iterator over the documents, which must be
+	// closed if it is not iterated until the end. The fields (fl) and the
+	// sort fields must have docValues.
+	//
+	// Refer to https://solr.apache.org/guide/8_8/exporting-result-sets.html
+	Export(ctx context.Context, collection, query, sort string, fl ...string) (*TupleStream, error)
+
 	// Suggester API
 
 	// Suggest queries the suggest endpoint.
@@ -0,0 +1,94 @@
+package solr
+
+import (
+	"net/url"
+	"strconv"
+)
+
+// CommitOptions is the commit and optimize param builder
+//
+// Refer to https://solr.apache.org/guide/8_8/uploading-data-with-index-handlers.html#commit-and-optimize-during-updates
+type CommitOptions struct {
+	softCommit     bool
+	expungeDeletes bool
+	waitSearcher   *bool
+	openSearcher   *bool
+
+	// optimize if true, an optimize is
+	// issued instead of a commit
+	optimize    bool
+	maxSegments int
+}
+
+// NewCommitOptions returns a new CommitOptions
+func NewCommitOptions() *CommitOptions {
+	return &CommitOptions{}
+}
+
+// SoftCommit if true, a soft commit is issued which makes the changes
+// visible without flushing the index segments to stable storage
+func (o *CommitOptions) SoftCommit(softCommit bool) *CommitOptions {
+	o.softCommit = softCommit
+	return o
+}
+
+// WaitSearcher sets whether to block until a new searcher
+// is opened and registered as the main query searcher.
+// The default is true.
+func (o *CommitOptions) WaitSearcher(waitSearcher bool) *CommitOptions {
+	o.waitSearcher = &waitSearcher
+	return o
+}
+
+// OpenSearcher sets whether to open a new searcher after the commit.
+// The default is true.
+func (o *CommitOptions) OpenSearcher(openSearcher bool) *CommitOptions {
+	o.openSearcher = &openSearcher
+	return o
+}
+
+// ExpungeDeletes if true, merges segments that have more than 10% deleted docs
+func (o *CommitOptions) ExpungeDeletes(expungeDeletes bool) *CommitOptions {
+	o.expungeDeletes = expungeDeletes
+	return o
+}
+
+// Optimize issues an optimize instead of a commit, merging the index down
+// to at most maxSegments segments. A maxSegments of zero uses the default of 1.
+func (o *CommitOptions) Optimize(maxSegments int) *CommitOptions {
+	o.optimize = true
+	o.maxSegments = maxSegments
+	return o
+}
+
+// BuildParams builds the parameters
+func (o *CommitOptions) BuildParams() string {
+	vals := &url.Values{}
+
+	if o.optimize {
+		vals.Add("optimize", "true")
+		if o.maxSegments > 0 {
+			vals.Add("maxSegments", strconv.Itoa(o.maxSegments))
+		}
+	} else {
+		vals.Add("commit", "true")
+	}
+
+	if o.softCommit {
+		vals.Add("softCommit", "true")
+	}
+
+	if o.waitSearcher != nil {
+		vals.Add("waitSearcher", strconv.FormatBool(*o.waitSearcher))
+	}
+
+	if o.openSearcher != nil {
+		vals.Add("openSearcher", strconv.FormatBool(*o.openSearcher))
+	}
+
+	if o.expungeDeletes {
+		vals.Add("expungeDeletes", "true")
+	}
+
+	return vals.Encode()
+}
@@ -0,0 +1,56 @@
+package solr_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/stevenferrer/solr-go"
+)
+
+func TestBuildCommitOptions(t *testing.T) {
+	tests := []struct {
+		name   string
+		opts   *solr.CommitOptions
+		expect string
+	}{
+		{
+			name:   "hard commit",
+			opts:   solr.NewCommitOptions(),
+			expect: "commit=true",
+		},
+		{
+			name: "hard commit without opening a searcher",
+			opts: solr.NewCommitOptions().
+				OpenSearcher(false).WaitSearcher(false).
+				ExpungeDeletes(true),
+			expect: "commit=true&expungeDeletes=true&openSearcher=false&waitSearcher=false",
+		},
+		{
+			name:   "soft commit",
+			opts:   solr.NewCommitOptions().SoftCommit(true).WaitSearcher(true),
+			expect: "commit=true&softCommit=true&waitSearcher=true",
+		},
+		{
+			name:   "optimize",
+			opts:   solr.NewCommitOptions().Optimize(0),
+			expect: "optimize=true",
+		},
+		{
+			name:   "optimize with max segments",
+			opts:   solr.NewCommitOptions().Optimize(4).WaitSearcher(false),
+			expect: "maxSegments=4&optimize=true&waitSearcher=false",
+		},
+		{
+			name:   "soft optimize",
+			opts:   solr.NewCommitOptions().Optimize(2).SoftCommit(true).ExpungeDeletes(true),
+			expect: "expungeDeletes=true&maxSegments=2&optimize=true&softCommit=true",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expect, tc.opts.BuildParams())
+		})
+	}
+}
@@ -0,0 +1,24 @@
+package solr
+
+import (
+	"bytes"
+	"encoding/json"
+)
+
+// decodeDocuments decodes the documents into v,
+// which is usually a pointer to a slice of structs
+func decodeDocuments(docs []M, v interface{}) error {
+	b, err := json.Marshal(docs)
+	if err != nil {
+		return wrapErr(err, "marshal documents")
+	}
+
+	dec := json.NewDecoder(bytes.NewReader(b))
+	dec.UseNumber()
+	err = dec.Decode(v)
+	if err != nil {
+		return wrapErr(err, "unmarshal documents")
+	}
+
+	return nil
+}
@@ -0,0 +1,40 @@
+package solr
+
+import (
+	"fmt"
+	"strings"
+)
+
+// sortFields returns the field names of the sort e.g. "a asc, b desc" is [a b]
+func sortFields(sort string) []string {
+	fields := []string{}
+	for _, clause := range strings.Split(sort, ",") {
+		parts := strings.Fields(clause)
+		if len(parts) > 0 {
+			fields = append(fields, parts[0])
+		}
+	}
+
+	return fields
+}
+
+// checkDocValues checks that the fields exist and have docValues enabled
+func checkDocValues(fields []Field, names []string) error {
+	docValues := map[string]bool{}
+	for _, field := range fields {
+		docValues[field.Name] = BoolValue(field.DocValues)
+	}
+
+	for _, name := range names {
+		hasDocValues, ok := docValues[name]
+		if !ok {
+			return fmt.Errorf("field %q not found", name)
+		}
+
+		if !hasDocValues {
+			return fmt.Errorf("field %q does not have docValues", name)
+		}
+	}
+
+	return nil
+}
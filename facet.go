@@ -1,5 +1,11 @@
 package solr
 
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
 // Faceter is an abstraction of a facet
 // e.g. terms, query, stats, range, heatmap etc.
 type Faceter interface {
@@ -15,21 +21,20 @@ type TermsFacet struct {
 	name string
 
 	// terms facet params
-	field  string
-	offset int
-	limit  int
-	sort   string
-
-	// overRequest int
-	// refine      bool
-	// overRefine  int
-	minCount int
-	// missing     bool
-	// numBuckets  bool
-	// allBuckets  bool
-	// prefix      string
-	// method      string
-	// prelimSort  string
+	field       string
+	offset      int
+	limit       int
+	sort        string
+	overRequest int
+	refine      bool
+	overRefine  int
+	minCount    int
+	missing     bool
+	numBuckets  bool
+	allBuckets  bool
+	prefix      string
+	method      string
+	prelimSort  string
 
 	facet M
 
@@ -63,10 +68,46 @@ func (f *TermsFacet) BuildFacet() M {
 		m["sort"] = f.sort
 	}
 
+	if f.overRequest != 0 {
+		m["overrequest"] = f.overRequest
+	}
+
+	if f.refine {
+		m["refine"] = true
+	}
+
+	if f.overRefine != 0 {
+		m["overrefine"] = f.overRefine
+	}
+
 	if f.minCount > 0 {
 		m["mincount"] = f.minCount
 	}
 
+	if f.missing {
+		m["missing"] = true
+	}
+
+	if f.numBuckets {
+		m["numBuckets"] = true
+	}
+
+	if f.allBuckets {
+		m["allBuckets"] = true
+	}
+
+	if f.prefix != "" {
+		m["prefix"] = f.prefix
+	}
+
+	if f.method != "" {
+		m["method"] = f.method
+	}
+
+	if f.prelimSort != "" {
+		m["prelim_sort"] = f.prelimSort
+	}
+
 	if len(f.facet) > 0 {
 		m["facet"] = f.facet
 	}
@@ -134,18 +175,87 @@ func (f *TermsFacet) MinCount(minCount int) *TermsFacet {
 	return f
 }
 
+// OverRequest sets the overrequest param, the number of buckets beyond
+// the limit to request from each shard in distributed searches
+func (f *TermsFacet) OverRequest(overRequest int) *TermsFacet {
+	f.overRequest = overRequest
+	return f
+}
+
+// Refine if true, enables refinement of the bucket
+// counts in distributed searches for accurate counts
+func (f *TermsFacet) Refine(refine bool) *TermsFacet {
+	f.refine = refine
+	return f
+}
+
+// OverRefine sets the overrefine param, the number of buckets
+// beyond the limit to consider during refinement
+func (f *TermsFacet) OverRefine(overRefine int) *TermsFacet {
+	f.overRefine = overRefine
+	return f
+}
+
+// Missing if true, returns a bucket for the
+// documents without a value for the field
+func (f *TermsFacet) Missing(missing bool) *TermsFacet {
+	f.missing = missing
+	return f
+}
+
+// NumBuckets if true, returns the number of distinct buckets
+func (f *TermsFacet) NumBuckets(numBuckets bool) *TermsFacet {
+	f.numBuckets = numBuckets
+	return f
+}
+
+// AllBuckets if true, returns a bucket that is the union of all buckets
+func (f *TermsFacet) AllBuckets(allBuckets bool) *TermsFacet {
+	f.allBuckets = allBuckets
+	return f
+}
+
+// Prefix sets the prefix param, which limits the terms
+// to those that start with the prefix
+func (f *TermsFacet) Prefix(prefix string) *TermsFacet {
+	f.prefix = prefix
+	return f
+}
+
+// Method sets the facet algorithm hint (dv, uif, dvhash, enum, stream or smart)
+func (f *TermsFacet) Method(method string) *TermsFacet {
+	f.method = method
+	return f
+}
+
+// PrelimSort sets the prelim_sort param, an approximate sort used
+// to select the buckets before the (more costly) sort is applied
+func (f *TermsFacet) PrelimSort(prelimSort string) *TermsFacet {
+	f.prelimSort = prelimSort
+	return f
+}
+
+// Domain sets the domain of the facet, replacing the key-value
+// pairs added through AddToDomain before it
+func (f *TermsFacet) Domain(domain *Domain) *TermsFacet {
+	f.domain = domain.BuildDomain()
+
+	return f
+}
+
 // QueryFacet is query facet
 type QueryFacet struct {
-	name  string
-	q     string
-	facet M
+	name   string
+	q      string
+	facet  M
+	domain M
 }
 
 var _ Faceter = (*QueryFacet)(nil)
 
 // NewQueryFacet retunrs a new QueryFacet
 func NewQueryFacet(name string) *QueryFacet {
-	return &QueryFacet{name: name, facet: M{}}
+	return &QueryFacet{name: name, facet: M{}, domain: M{}}
 }
 
 // BuildFacet builds the facet
@@ -159,6 +269,10 @@ func (f *QueryFacet) BuildFacet() M {
 		m["facet"] = f.facet
 	}
 
+	if len(f.domain) > 0 {
+		m["domain"] = f.domain
+	}
+
 	return m
 }
 
@@ -185,15 +299,420 @@ func (f *QueryFacet) AddToFacet(key string, value interface{}) *QueryFacet {
 	return f
 }
 
-// // RangeFacet is a range facet
-// type RangeFacet struct {
-// 	field   string
-// 	start   int
-// 	end     int
-// 	gap     int
-// 	hardend bool
-// 	other   string
-// 	include string
-// 	ranges  string
-// 	facet   M
-// }
+// Domain sets the domain of the facet
+func (f *QueryFacet) Domain(domain *Domain) *QueryFacet {
+	f.domain = domain.BuildDomain()
+	return f
+}
+
+// RangeFacet is a range facet
+//
+// Refer to https://solr.apache.org/guide/8_8/json-facet-api.html#range-facet
+type RangeFacet struct {
+	name string
+
+	// range facet params
+	field   string
+	start   interface{}
+	end     interface{}
+	gap     interface{}
+	hardEnd bool
+	other   []string
+	include []string
+	ranges  []FacetRange
+
+	facet  M
+	domain M
+}
+
+var _ Faceter = (*RangeFacet)(nil)
+
+// FacetRange is an arbitrary range in a range facet.
+// Range takes precedence over From and To if specified.
+type FacetRange struct {
+	// From is the lower bound of the range
+	From interface{}
+	// To is the upper bound of the range
+	To interface{}
+	// InclusiveFrom sets whether to include the lower bound,
+	// Solr includes it by default
+	InclusiveFrom *bool
+	// InclusiveTo sets whether to include the upper bound,
+	// Solr excludes it by default
+	InclusiveTo *bool
+	// Range is the range in interval notation e.g. "[40,100)"
+	Range string
+}
+
+// NewRangeFacet returns a new RangeFacet
+func NewRangeFacet(name string) *RangeFacet {
+	return &RangeFacet{name: name, facet: M{}}
+}
+
+// BuildFacet builds the facet
+func (f *RangeFacet) BuildFacet() M {
+	m := M{"type": "range"}
+
+	if f.field != "" {
+		m["field"] = f.field
+	}
+
+	if f.start != nil {
+		m["start"] = f.start
+	}
+
+	if f.end != nil {
+		m["end"] = f.end
+	}
+
+	if f.gap != nil {
+		m["gap"] = f.gap
+	}
+
+	if f.hardEnd {
+		m["hardend"] = true
+	}
+
+	if len(f.other) > 0 {
+		m["other"] = f.other
+	}
+
+	if len(f.include) > 0 {
+		m["include"] = f.include
+	}
+
+	if len(f.ranges) > 0 {
+		ranges := make([]M, 0, len(f.ranges))
+		for _, r := range f.ranges {
+			if r.Range != "" {
+				ranges = append(ranges, M{"range": r.Range})
+				continue
+			}
+
+			rm := M{}
+			if r.From != nil {
+				rm["from"] = r.From
+			}
+
+			if r.To != nil {
+				rm["to"] = r.To
+			}
+
+			if r.InclusiveFrom != nil {
+				rm["inclusive_from"] = *r.InclusiveFrom
+			}
+
+			if r.InclusiveTo != nil {
+				rm["inclusive_to"] = *r.InclusiveTo
+			}
+
+			ranges = append(ranges, rm)
+		}
+
+		m["ranges"] = ranges
+	}
+
+	if len(f.facet) > 0 {
+		m["facet"] = f.facet
+	}
+
+	if len(f.domain) > 0 {
+		m["domain"] = f.domain
+	}
+
+	return m
+}
+
+// Name returns the facet name
+func (f *RangeFacet) Name() string {
+	return f.name
+}
+
+// Field sets the field param
+func (f *RangeFacet) Field(field string) *RangeFacet {
+	f.field = field
+	return f
+}
+
+// Start sets the lower bound of the ranges.
+// Date fields accept date math e.g. "NOW/DAY-7DAYS".
+func (f *RangeFacet) Start(start interface{}) *RangeFacet {
+	f.start = start
+	return f
+}
+
+// End sets the upper bound of the ranges.
+// Date fields accept date math e.g. "NOW/DAY".
+func (f *RangeFacet) End(end interface{}) *RangeFacet {
+	f.end = end
+	return f
+}
+
+// Gap sets the size of each range bucket.
+// Date fields accept date math e.g. "+1DAY".
+func (f *RangeFacet) Gap(gap interface{}) *RangeFacet {
+	f.gap = gap
+	return f
+}
+
+// HardEnd if true, the last bucket ends at end
+// even if it is less than gap wide
+func (f *RangeFacet) HardEnd(hardEnd bool) *RangeFacet {
+	f.hardEnd = hardEnd
+	return f
+}
+
+// Other sets the other param, which adds counts for
+// the before, after and between ranges (also none and all)
+func (f *RangeFacet) Other(other ...string) *RangeFacet {
+	f.other = other
+	return f
+}
+
+// Include sets the include param, which controls the bucket
+// boundaries (lower, upper, edge, outer and all)
+func (f *RangeFacet) Include(include ...string) *RangeFacet {
+	f.include = include
+	return f
+}
+
+// Ranges sets the arbitrary ranges, used instead of start, end and gap
+func (f *RangeFacet) Ranges(ranges ...FacetRange) *RangeFacet {
+	f.ranges = ranges
+	return f
+}
+
+// AddFacets adds nested facets
+func (f *RangeFacet) AddFacets(facets ...Faceter) *RangeFacet {
+	for _, facet := range facets {
+		f.facet[facet.Name()] = facet.BuildFacet()
+	}
+
+	return f
+}
+
+// AddToFacet adds a key-value pair to the facet map
+func (f *RangeFacet) AddToFacet(key string, value interface{}) *RangeFacet {
+	f.facet[key] = value
+	return f
+}
+
+// Domain sets the domain of the facet
+func (f *RangeFacet) Domain(domain *Domain) *RangeFacet {
+	f.domain = domain.BuildDomain()
+	return f
+}
+
+// HeatmapFacet is a heatmap facet
+//
+// Refer to https://solr.apache.org/guide/8_8/json-facet-api.html#heatmap-facet
+type HeatmapFacet struct {
+	name string
+
+	// heatmap facet params
+	field      string
+	geom       string
+	gridLevel  int
+	distErrPct float64
+	distErr    float64
+	format     string
+}
+
+var _ Faceter = (*HeatmapFacet)(nil)
+
+// NewHeatmapFacet returns a new HeatmapFacet
+func NewHeatmapFacet(name string) *HeatmapFacet {
+	return &HeatmapFacet{name: name}
+}
+
+// BuildFacet builds the facet
+func (f *HeatmapFacet) BuildFacet() M {
+	m := M{"type": "heatmap"}
+
+	if f.field != "" {
+		m["field"] = f.field
+	}
+
+	if f.geom != "" {
+		m["geom"] = f.geom
+	}
+
+	if f.gridLevel > 0 {
+		m["gridLevel"] = f.gridLevel
+	}
+
+	if f.distErrPct > 0 {
+		m["distErrPct"] = f.distErrPct
+	}
+
+	if f.distErr > 0 {
+		m["distErr"] = f.distErr
+	}
+
+	if f.format != "" {
+		m["format"] = f.format
+	}
+
+	return m
+}
+
+// Name returns the facet name
+func (f *HeatmapFacet) Name() string {
+	return f.name
+}
+
+// Field sets the spatial (RPT) field param
+func (f *HeatmapFacet) Field(field string) *HeatmapFacet {
+	f.field = field
+	return f
+}
+
+// Geom sets the region to compute the heatmap on,
+// e.g. `["-180 -90" TO "180 90"]` or a WKT shape
+func (f *HeatmapFacet) Geom(geom string) *HeatmapFacet {
+	f.geom = geom
+	return f
+}
+
+// GridLevel sets the grid level of the heatmap
+func (f *HeatmapFacet) GridLevel(gridLevel int) *HeatmapFacet {
+	f.gridLevel = gridLevel
+	return f
+}
+
+// DistErrPct sets the fraction of the geom size
+// used to compute the grid level
+func (f *HeatmapFacet) DistErrPct(distErrPct float64) *HeatmapFacet {
+	f.distErrPct = distErrPct
+	return f
+}
+
+// DistErr sets the maximum cell error distance
+// used to compute the grid level
+func (f *HeatmapFacet) DistErr(distErr float64) *HeatmapFacet {
+	f.distErr = distErr
+	return f
+}
+
+// Format sets the format of the heatmap, either ints2D (default) or png
+func (f *HeatmapFacet) Format(format string) *HeatmapFacet {
+	f.format = format
+	return f
+}
+
+// StatFacet is an aggregation function (stat) facet
+//
+// Refer to https://solr.apache.org/guide/8_8/json-facet-api.html#stat-facet-functions
+type StatFacet struct {
+	name string
+	fn   string
+
+	// relatedness options
+	minPopularity float64
+}
+
+var _ Faceter = (*StatFacet)(nil)
+
+// NewStatFacet returns a new StatFacet
+func NewStatFacet(name string) *StatFacet {
+	return &StatFacet{name: name}
+}
+
+// BuildFacet builds the facet
+func (f *StatFacet) BuildFacet() M {
+	m := M{"type": "func", "func": f.fn}
+
+	if f.minPopularity > 0 {
+		m["min_popularity"] = f.minPopularity
+	}
+
+	return m
+}
+
+// Name returns the facet name
+func (f *StatFacet) Name() string {
+	return f.name
+}
+
+// Func sets an arbitrary aggregation function e.g. "sum(div(price,qty))"
+func (f *StatFacet) Func(fn string) *StatFacet {
+	f.fn = fn
+	return f
+}
+
+// Sum sets the sum of numeric values
+func (f *StatFacet) Sum(expr string) *StatFacet {
+	return f.Func(fmt.Sprintf("sum(%s)", expr))
+}
+
+// Avg sets the average of numeric values
+func (f *StatFacet) Avg(expr string) *StatFacet {
+	return f.Func(fmt.Sprintf("avg(%s)", expr))
+}
+
+// Min sets the minimum value
+func (f *StatFacet) Min(expr string) *StatFacet {
+	return f.Func(fmt.Sprintf("min(%s)", expr))
+}
+
+// Max sets the maximum value
+func (f *StatFacet) Max(expr string) *StatFacet {
+	return f.Func(fmt.Sprintf("max(%s)", expr))
+}
+
+// Unique sets the number of unique values of the field
+func (f *StatFacet) Unique(field string) *StatFacet {
+	return f.Func(fmt.Sprintf("unique(%s)", field))
+}
+
+// HLL sets the distributed cardinality estimate via hyper-log-log algorithm
+func (f *StatFacet) HLL(field string) *StatFacet {
+	return f.Func(fmt.Sprintf("hll(%s)", field))
+}
+
+// Percentile sets the percentile estimates via t-digest algorithm
+func (f *StatFacet) Percentile(expr string, percentiles ...float64) *StatFacet {
+	args := []string{expr}
+	for _, p := range percentiles {
+		args = append(args, strconv.FormatFloat(p, 'f', -1, 64))
+	}
+
+	return f.Func(fmt.Sprintf("percentile(%s)", strings.Join(args, ",")))
+}
+
+// SumSq sets the sum of squares of the field or function
+func (f *StatFacet) SumSq(expr string) *StatFacet {
+	return f.Func(fmt.Sprintf("sumsq(%s)", expr))
+}
+
+// Variance sets the variance of the numeric field or function
+func (f *StatFacet) Variance(expr string) *StatFacet {
+	return f.Func(fmt.Sprintf("variance(%s)", expr))
+}
+
+// StdDev sets the standard deviation of the numeric field or function
+func (f *StatFacet) StdDev(expr string) *StatFacet {
+	return f.Func(fmt.Sprintf("stddev(%s)", expr))
+}
+
+// CountVals sets the number of values of a numeric or string field
+func (f *StatFacet) CountVals(field string) *StatFacet {
+	return f.Func(fmt.Sprintf("countvals(%s)", field))
+}
+
+// Missing sets the number of documents that do not have a value for the field
+func (f *StatFacet) Missing(field string) *StatFacet {
+	return f.Func(fmt.Sprintf("missing(%s)", field))
+}
+
+// Relatedness sets the relatedness score of the bucket, relative
+// to the foreground and background sets e.g. "$fore" and "$back"
+func (f *StatFacet) Relatedness(fore, back string) *StatFacet {
+	return f.Func(fmt.Sprintf("relatedness(%s,%s)", fore, back))
+}
+
+// MinPopularity sets the min_popularity option of the relatedness function
+func (f *StatFacet) MinPopularity(minPopularity float64) *StatFacet {
+	f.minPopularity = minPopularity
+	return f
+}
@@ -0,0 +1,104 @@
+package solr
+
+// Domain is a facet domain builder, used to change the
+// domain of documents the facet is calculated on
+//
+// Refer to https://solr.apache.org/guide/8_8/json-faceting-domain-changes.html
+type Domain struct {
+	excludeTags   []string
+	filters       []string
+	queries       []string
+	blockParent   string
+	blockChildren string
+	join          M
+	graph         M
+}
+
+// NewDomain returns a new Domain
+func NewDomain() *Domain {
+	return &Domain{}
+}
+
+// ExcludeTags sets the excludeTags param, used to exclude
+// tagged filters from the domain e.g. for multi-select faceting
+func (d *Domain) ExcludeTags(tags ...string) *Domain {
+	d.excludeTags = tags
+	return d
+}
+
+// Filters sets the filter param, used to filter the domain
+func (d *Domain) Filters(filters ...string) *Domain {
+	d.filters = filters
+	return d
+}
+
+// Queries sets the query param, used to replace the domain
+// with the documents matching the queries
+func (d *Domain) Queries(queries ...string) *Domain {
+	d.queries = queries
+	return d
+}
+
+// BlockParent sets the blockParent param, used to map
+// the child documents in the domain to their parents
+func (d *Domain) BlockParent(blockParent string) *Domain {
+	d.blockParent = blockParent
+	return d
+}
+
+// BlockChildren sets the blockChildren param, used to map
+// the parent documents in the domain to their children
+func (d *Domain) BlockChildren(blockChildren string) *Domain {
+	d.blockChildren = blockChildren
+	return d
+}
+
+// Join sets the join param, used to change the domain to the documents
+// whose to field match the from field values of the current domain
+func (d *Domain) Join(from, to string) *Domain {
+	d.join = M{"from": from, "to": to}
+	return d
+}
+
+// Graph sets the graph param, used to change the domain by
+// traversing the graph from the current domain, following the
+// edges from the from field to the to field
+func (d *Domain) Graph(from, to string) *Domain {
+	d.graph = M{"from": from, "to": to}
+	return d
+}
+
+// BuildDomain builds the domain
+func (d *Domain) BuildDomain() M {
+	m := M{}
+
+	if len(d.excludeTags) > 0 {
+		m["excludeTags"] = d.excludeTags
+	}
+
+	if len(d.filters) > 0 {
+		m["filter"] = d.filters
+	}
+
+	if len(d.queries) > 0 {
+		m["query"] = d.queries
+	}
+
+	if d.blockParent != "" {
+		m["blockParent"] = d.blockParent
+	}
+
+	if d.blockChildren != "" {
+		m["blockChildren"] = d.blockChildren
+	}
+
+	if d.join != nil {
+		m["join"] = d.join
+	}
+
+	if d.graph != nil {
+		m["graph"] = d.graph
+	}
+
+	return m
+}
@@ -0,0 +1,35 @@
+package solr_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/stevenferrer/solr-go"
+)
+
+func TestDomain(t *testing.T) {
+	got := solr.NewDomain().BuildDomain()
+	assert.Equal(t, solr.M{}, got)
+
+	got = solr.NewDomain().
+		ExcludeTags("color_tag", "size_tag").
+		Filters("inStock:true").
+		Queries("type:product").
+		BlockParent("type:product").
+		BlockChildren("type:product").
+		Join("manu_id_s", "id").
+		Graph("parent_id", "id").
+		BuildDomain()
+
+	expect := solr.M{
+		"excludeTags":   []string{"color_tag", "size_tag"},
+		"filter":        []string{"inStock:true"},
+		"query":         []string{"type:product"},
+		"blockParent":   "type:product",
+		"blockChildren": "type:product",
+		"join":          solr.M{"from": "manu_id_s", "to": "id"},
+		"graph":         solr.M{"from": "parent_id", "to": "id"},
+	}
+	assert.Equal(t, expect, got)
+}
@@ -0,0 +1,160 @@
+package solr
+
+import "encoding/json"
+
+// FacetResult is the typed result of a facet. It mirrors the facet
+// request: the top-level result (and every bucket) holds the document
+// count, the stat values and the nested facet results by name.
+//
+// Refer to https://solr.apache.org/guide/8_8/json-facet-api.html
+type FacetResult struct {
+	// Val is the bucket value, only set for buckets
+	Val interface{}
+	// Count is the number of documents in the domain or bucket
+	Count int
+	// Buckets is the list of buckets of a terms or range facet
+	Buckets []*FacetResult
+	// NumBuckets is the number of distinct buckets (numBuckets:true)
+	NumBuckets int
+	// Missing is the bucket for documents without a value (missing:true)
+	Missing *FacetResult
+	// AllBuckets is the union of all buckets (allBuckets:true)
+	AllBuckets *FacetResult
+	// Before is the range before start (other:before)
+	Before *FacetResult
+	// After is the range after end (other:after)
+	After *FacetResult
+	// Between is the range between start and end (other:between)
+	Between *FacetResult
+	// Stats is the stat (aggregation function) values by name
+	Stats M
+	// Facets is the nested facet results by name, it includes
+	// the special buckets e.g. missing under their own keys
+	Facets map[string]*FacetResult
+}
+
+// NewFacetResult parses the facets section of a query response
+func NewFacetResult(m M) *FacetResult {
+	r := &FacetResult{Stats: M{}, Facets: map[string]*FacetResult{}}
+
+	for k, v := range m {
+		sub, isMap := toM(v)
+		switch {
+		case k == "val":
+			r.Val = v
+		case k == "count" && !isMap:
+			r.Count = toInt(v)
+		case k == "numBuckets" && !isMap:
+			r.NumBuckets = toInt(v)
+		case k == "buckets":
+			r.Buckets = parseBuckets(v)
+		case isMap && !isRelatedness(sub):
+			// a nested facet can have the same name as the special
+			// buckets, so they are also kept under their own keys
+			r.Facets[k] = NewFacetResult(sub)
+			switch k {
+			case "missing":
+				r.Missing = r.Facets[k]
+			case "allBuckets":
+				r.AllBuckets = r.Facets[k]
+			case "before":
+				r.Before = r.Facets[k]
+			case "after":
+				r.After = r.Facets[k]
+			case "between":
+				r.Between = r.Facets[k]
+			}
+		default:
+			r.Stats[k] = v
+		}
+	}
+
+	return r
+}
+
+// Facet returns the nested facet result with the
+// given name, or nil if there is no such facet
+func (r *FacetResult) Facet(name string) *FacetResult {
+	if r == nil {
+		return nil
+	}
+
+	return r.Facets[name]
+}
+
+// Stat returns the numeric stat value with the given name. The
+// second return value is false if the stat is missing or not a number.
+func (r *FacetResult) Stat(name string) (float64, bool) {
+	if r == nil {
+		return 0, false
+	}
+
+	switch val := r.Stats[name].(type) {
+	case json.Number:
+		f, err := val.Float64()
+		return f, err == nil
+	case float64:
+		return val, true
+	case int:
+		return float64(val), true
+	case int64:
+		return float64(val), true
+	}
+
+	return 0, false
+}
+
+// parseBuckets parses the list of buckets
+func parseBuckets(v interface{}) []*FacetResult {
+	list, ok := v.([]interface{})
+	if !ok {
+		return nil
+	}
+
+	buckets := make([]*FacetResult, 0, len(list))
+	for _, item := range list {
+		if m, ok := toM(item); ok {
+			buckets = append(buckets, NewFacetResult(m))
+		}
+	}
+
+	return buckets
+}
+
+// isRelatedness returns true if m is the result of a relatedness stat
+func isRelatedness(m M) bool {
+	_, ok := m["relatedness"]
+	return ok
+}
+
+// toM converts a decoded json object to M
+func toM(v interface{}) (M, bool) {
+	switch val := v.(type) {
+	case M:
+		return val, true
+	case map[string]interface{}:
+		return val, true
+	}
+
+	return nil, false
+}
+
+// toInt converts a decoded json number to int
+func toInt(v interface{}) int {
+	switch val := v.(type) {
+	case json.Number:
+		if n, err := val.Int64(); err == nil {
+			return int(n)
+		}
+		f, _ := val.Float64()
+		return int(f)
+	case float64:
+		return int(val)
+	case int:
+		return val
+	case int64:
+		return int(val)
+	}
+
+	return 0
+}
@@ -0,0 +1,108 @@
+package solr_test
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/stevenferrer/solr-go"
+)
+
+func TestFacetResult(t *testing.T) {
+	body := `{
+		"facets": {
+			"count": 32,
+			"avg_price": 164.5,
+			"brands": {
+				"numBuckets": 2,
+				"allBuckets": {"count": 30},
+				"missing": {"count": 2, "avg_price": 10},
+				"buckets": [
+					{"val": "apple", "count": 20, "avg_price": 200.5,
+						"colors": {"buckets": [{"val": "red", "count": 12}]}},
+					{"val": "samsung", "count": 10, "avg_price": 120,
+						"r": {"relatedness": 0.2, "foreground_popularity": 0.1, "background_popularity": 0.3}}
+				]
+			},
+			"prices": {
+				"buckets": [{"val": 0, "count": 5}, {"val": 100, "count": 8}],
+				"before": {"count": 1},
+				"after": {"count": 3},
+				"between": {"count": 13}
+			},
+			"high_popularity": {"count": 7, "p": [10, 20]}
+		}
+	}`
+
+	var resp solr.QueryResponse
+	err := json.Unmarshal([]byte(body), &resp)
+	require.NoError(t, err)
+
+	result := resp.FacetResults()
+	require.NotNil(t, result)
+	assert.Equal(t, 32, result.Count)
+
+	avgPrice, ok := result.Stat("avg_price")
+	assert.True(t, ok)
+	assert.Equal(t, 164.5, avgPrice)
+
+	brands := result.Facet("brands")
+	require.NotNil(t, brands)
+	assert.Equal(t, 2, brands.NumBuckets)
+	assert.Equal(t, 30, brands.AllBuckets.Count)
+	assert.Equal(t, 2, brands.Missing.Count)
+	require.Len(t, brands.Buckets, 2)
+	assert.Equal(t, "apple", brands.Buckets[0].Val)
+	assert.Equal(t, 20, brands.Buckets[0].Count)
+	assert.Equal(t, 12, brands.Buckets[0].Facet("colors").Buckets[0].Count)
+	assert.Equal(t, map[string]interface{}{
+		"relatedness":           0.2,
+		"foreground_popularity": 0.1,
+		"background_popularity": 0.3,
+	}, brands.Buckets[1].Stats["r"])
+
+	_, ok = brands.Buckets[1].Stat("r")
+	assert.False(t, ok)
+
+	prices := result.Facet("prices")
+	require.NotNil(t, prices)
+	assert.Len(t, prices.Buckets, 2)
+	assert.Equal(t, 1, prices.Before.Count)
+	assert.Equal(t, 3, prices.After.Count)
+	assert.Equal(t, 13, prices.Between.Count)
+
+	highPopularity := result.Facet("high_popularity")
+	assert.Equal(t, 7, highPopularity.Count)
+	assert.Equal(t, []interface{}{float64(10), float64(20)}, highPopularity.Stats["p"])
+
+	assert.Nil(t, result.Facet("missing"))
+	assert.Nil(t, result.Facet("missing").Facet("nested"))
+	_, ok = result.Facet("missing").Stat("count")
+	assert.False(t, ok)
+
+	assert.Nil(t, (&solr.QueryResponse{}).FacetResults())
+
+	t.Run("json numbers", func(t *testing.T) {
+		dec := json.NewDecoder(strings.NewReader(`{"count":32,"avg_price":164.5,
+			"after":{"count":4,"buckets":[{"val":"x","count":4}]}}`))
+		dec.UseNumber()
+
+		var m solr.M
+		require.NoError(t, dec.Decode(&m))
+
+		result := solr.NewFacetResult(m)
+		assert.Equal(t, 32, result.Count)
+
+		avgPrice, ok := result.Stat("avg_price")
+		assert.True(t, ok)
+		assert.Equal(t, 164.5, avgPrice)
+
+		// a user facet named like a special bucket
+		require.NotNil(t, result.Facet("after"))
+		assert.Equal(t, 4, result.Facet("after").Buckets[0].Count)
+		assert.Same(t, result.After, result.Facet("after"))
+	})
+}
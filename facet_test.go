@@ -42,6 +42,61 @@ func TestFacets(t *testing.T) {
 		assert.Equal(t, expect, got)
 	})
 
+	t.Run("terms facet options", func(t *testing.T) {
+		got := solr.NewTermsFacet("colors").
+			Field("color").
+			OverRequest(20).Refine(true).OverRefine(10).
+			Missing(true).NumBuckets(true).AllBuckets(true).
+			Prefix("bl").Method("dv").PrelimSort("count desc").
+			Domain(solr.NewDomain().ExcludeTags("color_tag")).
+			BuildFacet()
+
+		expect := solr.M{
+			"type":        "terms",
+			"field":       "color",
+			"overrequest": 20,
+			"refine":      true,
+			"overrefine":  10,
+			"missing":     true,
+			"numBuckets":  true,
+			"allBuckets":  true,
+			"prefix":      "bl",
+			"method":      "dv",
+			"prelim_sort": "count desc",
+			"domain":      solr.M{"excludeTags": []string{"color_tag"}},
+		}
+		assert.Equal(t, expect, got)
+	})
+
+	t.Run("terms facet domain replaces", func(t *testing.T) {
+		got := solr.NewTermsFacet("colors").
+			AddToDomain("filter", "inStock:true").
+			Domain(solr.NewDomain().ExcludeTags("color_tag")).
+			AddToDomain("blockChildren", "type:product").
+			BuildFacet()
+
+		expect := solr.M{
+			"type": "terms",
+			"domain": solr.M{
+				"excludeTags":   []string{"color_tag"},
+				"blockChildren": "type:product",
+			},
+		}
+		assert.Equal(t, expect, got)
+	})
+
+	t.Run("facet domains", func(t *testing.T) {
+		domain := solr.NewDomain().BlockChildren("type:product")
+
+		got := solr.NewQueryFacet("red").Query("color:red").
+			Domain(domain).BuildFacet()
+		assert.Equal(t, solr.M{"blockChildren": "type:product"}, got["domain"])
+
+		got = solr.NewRangeFacet("prices").Field("price").
+			Domain(domain).BuildFacet()
+		assert.Equal(t, solr.M{"blockChildren": "type:product"}, got["domain"])
+	})
+
 	t.Run("query facet", func(f *testing.T) {
 		termsFacet := solr.NewTermsFacet("categories").
 			Field("cat").Limit(5)
@@ -67,4 +122,134 @@ func TestFacets(t *testing.T) {
 		assert.Equal(t, "high_popularity", facet.Name())
 		assert.Equal(t, expect, got)
 	})
+
+	t.Run("range facet", func(t *testing.T) {
+		facet := solr.NewRangeFacet("prices").
+			Field("price").Start(0).End(100).Gap(20).
+			HardEnd(true).Other("before", "after").
+			Include("lower", "edge").
+			AddFacets(solr.NewStatFacet("avg_popularity").Avg("popularity")).
+			AddToFacet("max_price", "max(price)")
+		got := facet.BuildFacet()
+
+		expect := solr.M{
+			"type":    "range",
+			"field":   "price",
+			"start":   0,
+			"end":     100,
+			"gap":     20,
+			"hardend": true,
+			"other":   []string{"before", "after"},
+			"include": []string{"lower", "edge"},
+			"facet": solr.M{
+				"avg_popularity": solr.M{"type": "func", "func": "avg(popularity)"},
+				"max_price":      "max(price)",
+			},
+		}
+
+		assert.Equal(t, "prices", facet.Name())
+		assert.Equal(t, expect, got)
+
+		got = solr.NewRangeFacet("manufacturedate").
+			Field("manufacturedate_dt").
+			Start("NOW/YEAR-10YEARS").End("NOW/YEAR").Gap("+1YEAR").
+			BuildFacet()
+		expect = solr.M{
+			"type":  "range",
+			"field": "manufacturedate_dt",
+			"start": "NOW/YEAR-10YEARS",
+			"end":   "NOW/YEAR",
+			"gap":   "+1YEAR",
+		}
+		assert.Equal(t, expect, got)
+
+		got = solr.NewRangeFacet("price_ranges").
+			Field("price").
+			Ranges(
+				solr.FacetRange{From: 0, To: 50, InclusiveFrom: solr.Bool(true)},
+				solr.FacetRange{From: 50, InclusiveFrom: solr.Bool(false), InclusiveTo: solr.Bool(true)},
+				solr.FacetRange{Range: "[40,100)"},
+			).
+			BuildFacet()
+		expect = solr.M{
+			"type":  "range",
+			"field": "price",
+			"ranges": []solr.M{
+				{"from": 0, "to": 50, "inclusive_from": true},
+				{"from": 50, "inclusive_from": false, "inclusive_to": true},
+				{"range": "[40,100)"},
+			},
+		}
+		assert.Equal(t, expect, got)
+	})
+
+	t.Run("heatmap facet", func(t *testing.T) {
+		facet := solr.NewHeatmapFacet("locations").
+			Field("location_srpt").
+			Geom(`["-180 -90" TO "180 90"]`).
+			GridLevel(2).DistErrPct(0.15).DistErr(10).
+			Format("ints2D")
+		got := facet.BuildFacet()
+
+		expect := solr.M{
+			"type":       "heatmap",
+			"field":      "location_srpt",
+			"geom":       `["-180 -90" TO "180 90"]`,
+			"gridLevel":  2,
+			"distErrPct": 0.15,
+			"distErr":    float64(10),
+			"format":     "ints2D",
+		}
+
+		assert.Equal(t, "locations", facet.Name())
+		assert.Equal(t, expect, got)
+	})
+
+	t.Run("stat facets", func(t *testing.T) {
+		tests := []struct {
+			facet  *solr.StatFacet
+			expect string
+		}{
+			{solr.NewStatFacet("s").Sum("price"), "sum(price)"},
+			{solr.NewStatFacet("s").Avg("price"), "avg(price)"},
+			{solr.NewStatFacet("s").Min("price"), "min(price)"},
+			{solr.NewStatFacet("s").Max("price"), "max(price)"},
+			{solr.NewStatFacet("s").Unique("brand"), "unique(brand)"},
+			{solr.NewStatFacet("s").HLL("brand"), "hll(brand)"},
+			{solr.NewStatFacet("s").Percentile("price", 50, 99.9), "percentile(price,50,99.9)"},
+			{solr.NewStatFacet("s").SumSq("price"), "sumsq(price)"},
+			{solr.NewStatFacet("s").Variance("price"), "variance(price)"},
+			{solr.NewStatFacet("s").StdDev("price"), "stddev(price)"},
+			{solr.NewStatFacet("s").CountVals("brand"), "countvals(brand)"},
+			{solr.NewStatFacet("s").Missing("brand"), "missing(brand)"},
+			{solr.NewStatFacet("s").Func("sum(div(price,qty))"), "sum(div(price,qty))"},
+		}
+
+		for _, tc := range tests {
+			expect := solr.M{"type": "func", "func": tc.expect}
+			assert.Equal(t, expect, tc.facet.BuildFacet())
+		}
+
+		facet := solr.NewStatFacet("r").
+			Relatedness("$fore", "$back").MinPopularity(0.001)
+		expect := solr.M{
+			"type":           "func",
+			"func":           "relatedness($fore,$back)",
+			"min_popularity": 0.001,
+		}
+		assert.Equal(t, "r", facet.Name())
+		assert.Equal(t, expect, facet.BuildFacet())
+
+		got := solr.NewTermsFacet("categories").Field("cat").
+			AddFacets(solr.NewStatFacet("total").Sum("price")).
+			BuildFacet()
+		assert.Equal(t, solr.M{"type": "func", "func": "sum(price)"},
+			got["facet"].(solr.M)["total"])
+
+		got = solr.NewQueryFacet("cheap").Query("price:[* TO 10]").
+			AddFacet(solr.NewStatFacet("total").Sum("price")).
+			BuildFacet()
+		assert.Equal(t, solr.M{"type": "func", "func": "sum(price)"},
+			got["facet"].(solr.M)["total"])
+	})
 }
@@ -0,0 +1,253 @@
+package solr
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Function is a function query builder. A function can be used in sort
+// e.g. Sort(f.String() + " desc"), as a pseudo-field e.g. Fields("score:" + f.String()),
+// and with the frange (FrangeQueryParser) and boost (BoostQueryParser) query parsers.
+// Use Build to check the number of arguments of each function, String and the
+// query parsers build the function as is.
+//
+// Refer to https://solr.apache.org/guide/8_8/function-queries.html
+type Function struct {
+	name  string
+	args  []*Function
+	value string // value of a field, constant or raw expression
+}
+
+// funcArity is the minimum and maximum number of
+// arguments of a function, -1 means no maximum
+type funcArity struct {
+	min, max int
+}
+
+// funcArities is the number of arguments of the known functions,
+// functions not in this list are not checked
+var funcArities = map[string]funcArity{
+	"sum":     {1, -1},
+	"sub":     {2, 2},
+	"product": {1, -1},
+	"div":     {2, 2},
+	"mod":     {2, 2},
+	"pow":     {2, 2},
+	"abs":     {1, 1},
+	"log":     {1, 1},
+	"ln":      {1, 1},
+	"sqrt":    {1, 1},
+	"max":     {1, -1},
+	"min":     {1, -1},
+	"recip":   {4, 4},
+	"linear":  {3, 3},
+	"scale":   {3, 3},
+	"if":      {3, 3},
+	"exists":  {1, 1},
+	"def":     {2, 2},
+	"not":     {1, 1},
+	"and":     {2, -1},
+	"or":      {2, -1},
+	"query":   {1, 2},
+	"ms":      {0, 2},
+}
+
+// FuncField returns the value of the field
+func FuncField(field string) *Function {
+	return &Function{value: field}
+}
+
+// FuncConst returns a numeric constant
+func FuncConst(v float64) *Function {
+	return &Function{value: formatFloat(v)}
+}
+
+// FuncStr returns a string constant
+func FuncStr(s string) *Function {
+	return &Function{value: strconv.Quote(s)}
+}
+
+// FuncParam returns a parameter reference e.g. FuncParam("qq") is $qq
+func FuncParam(name string) *Function {
+	return &Function{value: "$" + name}
+}
+
+// FuncRaw returns the raw expression as is e.g. FuncRaw("NOW/DAY")
+func FuncRaw(expr string) *Function {
+	return &Function{value: expr}
+}
+
+// NewFunction returns the function with the given name and arguments, which
+// can be used for functions that don't have a dedicated constructor
+func NewFunction(name string, args ...*Function) *Function {
+	return &Function{name: name, args: args}
+}
+
+// FuncSum returns the sum of the values
+func FuncSum(args ...*Function) *Function {
+	return NewFunction("sum", args...)
+}
+
+// FuncSub returns x-y
+func FuncSub(x, y *Function) *Function {
+	return NewFunction("sub", x, y)
+}
+
+// FuncProduct returns the product of the values
+func FuncProduct(args ...*Function) *Function {
+	return NewFunction("product", args...)
+}
+
+// FuncDiv returns x/y
+func FuncDiv(x, y *Function) *Function {
+	return NewFunction("div", x, y)
+}
+
+// FuncPow returns x raised to the power of y
+func FuncPow(x, y *Function) *Function {
+	return NewFunction("pow", x, y)
+}
+
+// FuncAbs returns the absolute value of x
+func FuncAbs(x *Function) *Function {
+	return NewFunction("abs", x)
+}
+
+// FuncLog returns the base 10 logarithm of x
+func FuncLog(x *Function) *Function {
+	return NewFunction("log", x)
+}
+
+// FuncSqrt returns the square root of x
+func FuncSqrt(x *Function) *Function {
+	return NewFunction("sqrt", x)
+}
+
+// FuncMax returns the maximum of the values
+func FuncMax(args ...*Function) *Function {
+	return NewFunction("max", args...)
+}
+
+// FuncMin returns the minimum of the values
+func FuncMin(args ...*Function) *Function {
+	return NewFunction("min", args...)
+}
+
+// FuncRecip returns the reciprocal function a/(m*x+b)
+func FuncRecip(x *Function, m, a, b float64) *Function {
+	return NewFunction("recip", x, FuncConst(m), FuncConst(a), FuncConst(b))
+}
+
+// FuncScale scales the values of x to be between min and max
+func FuncScale(x *Function, min, max float64) *Function {
+	return NewFunction("scale", x, FuncConst(min), FuncConst(max))
+}
+
+// FuncIf returns then if cond is true, otherwise returns els
+func FuncIf(cond, then, els *Function) *Function {
+	return NewFunction("if", cond, then, els)
+}
+
+// FuncExists returns true if x has a value
+func FuncExists(x *Function) *Function {
+	return NewFunction("exists", x)
+}
+
+// FuncDef returns the value of x, or def if x has no value
+func FuncDef(x, def *Function) *Function {
+	return NewFunction("def", x, def)
+}
+
+// FuncQuery returns the score of the query, or the optional
+// default value for documents that don't match the query
+// e.g. FuncQuery(FuncParam("qq"), FuncConst(0)) is query($qq,0)
+func FuncQuery(q *Function, def ...*Function) *Function {
+	return NewFunction("query", append([]*Function{q}, def...)...)
+}
+
+// FuncMs returns the milliseconds since epoch of a date (ms(a)),
+// the difference in milliseconds of two dates (ms(a,b)),
+// or the current time in milliseconds (ms())
+// e.g. FuncMs(FuncRaw("NOW"), FuncField("mydate"))
+func FuncMs(args ...*Function) *Function {
+	return NewFunction("ms", args...)
+}
+
+// Validate checks the number of arguments of
+// the function and all of its arguments
+func (f *Function) Validate() error {
+	if f == nil {
+		return fmt.Errorf("function is nil")
+	}
+
+	if f.name == "" {
+		return nil
+	}
+
+	if arity, ok := funcArities[f.name]; ok {
+		n := len(f.args)
+		if n < arity.min || (arity.max >= 0 && n > arity.max) {
+			return fmt.Errorf("%s: expecting %s but got %d",
+				f.name, arity, n)
+		}
+	}
+
+	for _, arg := range f.args {
+		err := arg.Validate()
+		if err != nil {
+			return wrapErr(err, f.name)
+		}
+	}
+
+	return nil
+}
+
+// Build validates and builds the function
+func (f *Function) Build() (string, error) {
+	err := f.Validate()
+	if err != nil {
+		return "", err
+	}
+
+	return f.String(), nil
+}
+
+// String implements Stringer, it builds the function without validating it
+func (f *Function) String() string {
+	if f == nil {
+		return ""
+	}
+
+	if f.name == "" {
+		return f.value
+	}
+
+	args := make([]string, 0, len(f.args))
+	for _, arg := range f.args {
+		args = append(args, arg.String())
+	}
+
+	return fmt.Sprintf("%s(%s)", f.name, strings.Join(args, ","))
+}
+
+// String implements Stringer
+func (a funcArity) String() string {
+	switch {
+	case a.min == a.max:
+		return pluralArguments(a.min)
+	case a.max < 0:
+		return "at least " + pluralArguments(a.min)
+	}
+
+	return fmt.Sprintf("%d to %s", a.min, pluralArguments(a.max))
+}
+
+// pluralArguments returns the number of arguments e.g. "1 argument"
+func pluralArguments(n int) string {
+	if n == 1 {
+		return "1 argument"
+	}
+
+	return fmt.Sprintf("%d arguments", n)
+}
@@ -0,0 +1,105 @@
+package solr_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/stevenferrer/solr-go"
+)
+
+func TestFunction(t *testing.T) {
+	t.Run("build", func(t *testing.T) {
+		var tests = []struct {
+			name   string
+			f      *solr.Function
+			expect string
+		}{
+			{
+				"field",
+				solr.FuncField("popularity"),
+				"popularity",
+			},
+			{
+				"arithmetic",
+				solr.FuncDiv(solr.FuncSum(solr.FuncField("x"), solr.FuncConst(1.5)), solr.FuncSub(solr.FuncField("y"), solr.FuncConst(2))),
+				"div(sum(x,1.5),sub(y,2))",
+			},
+			{
+				"recip of date",
+				solr.FuncRecip(solr.FuncMs(solr.FuncRaw("NOW"), solr.FuncField("mydate")), 3.16e-11, 1, 1),
+				"recip(ms(NOW,mydate),0.0000000000316,1,1)",
+			},
+			{
+				"log and scale",
+				solr.FuncScale(solr.FuncLog(solr.FuncField("popularity")), 0, 1),
+				"scale(log(popularity),0,1)",
+			},
+			{
+				"if exists def",
+				solr.FuncIf(solr.FuncExists(solr.FuncField("price")), solr.FuncDef(solr.FuncField("price"), solr.FuncConst(0)), solr.FuncConst(-1)),
+				"if(exists(price),def(price,0),-1)",
+			},
+			{
+				"query",
+				solr.FuncProduct(solr.FuncQuery(solr.FuncParam("qq"), solr.FuncConst(0.1)), solr.FuncPow(solr.FuncField("boost"), solr.FuncConst(2))),
+				"product(query($qq,0.1),pow(boost,2))",
+			},
+			{
+				"generic",
+				solr.NewFunction("termfreq", solr.FuncField("text"), solr.FuncStr("memory")),
+				`termfreq(text,"memory")`,
+			},
+			{
+				"ms",
+				solr.FuncMs(),
+				"ms()",
+			},
+		}
+
+		for _, test := range tests {
+			t.Run(test.name, func(t *testing.T) {
+				got, err := test.f.Build()
+				require.NoError(t, err)
+				assert.Equal(t, test.expect, got)
+			})
+		}
+	})
+
+	t.Run("validate", func(t *testing.T) {
+		var tests = []struct {
+			name string
+			f    *solr.Function
+		}{
+			{"no sum args", solr.FuncSum()},
+			{"too many ms args", solr.FuncMs(solr.FuncRaw("NOW"), solr.FuncRaw("NOW"), solr.FuncRaw("NOW"))},
+			{"too few recip args", solr.NewFunction("recip", solr.FuncField("x"))},
+			{"nested", solr.FuncLog(solr.FuncMax())},
+			{"too many query args", solr.FuncQuery(solr.FuncParam("qq"), solr.FuncConst(0), solr.FuncConst(1))},
+			{"nil arg", solr.FuncAbs(nil)},
+		}
+
+		for _, test := range tests {
+			t.Run(test.name, func(t *testing.T) {
+				_, err := test.f.Build()
+				assert.Error(t, err)
+			})
+		}
+
+		_, err := solr.FuncLog(solr.FuncMax()).Build()
+		assert.EqualError(t, err, "log: max: expecting at least 1 argument but got 0")
+	})
+
+	t.Run("sort and fields", func(t *testing.T) {
+		f, err := solr.FuncDiv(solr.FuncField("popularity"), solr.FuncField("price")).Build()
+		require.NoError(t, err)
+
+		got := solr.NewQuery("*:*").
+			Sort(f+" desc").
+			Fields("id", "ratio:"+f).
+			BuildQuery()
+		assert.Equal(t, "div(popularity,price) desc", got["sort"])
+		assert.Equal(t, []string{"id", "ratio:div(popularity,price)"}, got["fields"])
+	})
+}
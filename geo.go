@@ -0,0 +1,150 @@
+package solr
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// LatLon is a latitude and longitude point, which serializes to
+// the "lat,lon" format used by LatLonPointSpatialField
+//
+// Refer to https://solr.apache.org/guide/8_8/spatial-search.html#indexing-points
+type LatLon struct {
+	Lat float64
+	Lon float64
+}
+
+var (
+	_ json.Marshaler   = LatLon{}
+	_ json.Unmarshaler = (*LatLon)(nil)
+)
+
+// String implements Stringer
+func (ll LatLon) String() string {
+	return formatFloat(ll.Lat) + "," + formatFloat(ll.Lon)
+}
+
+// MarshalJSON implements json.Marshaler
+func (ll LatLon) MarshalJSON() ([]byte, error) {
+	return json.Marshal(ll.String())
+}
+
+// UnmarshalJSON implements json.Unmarshaler
+func (ll *LatLon) UnmarshalJSON(b []byte) error {
+	var s string
+	err := json.Unmarshal(b, &s)
+	if err != nil {
+		return err
+	}
+
+	parts := strings.Split(s, ",")
+	if len(parts) != 2 {
+		return fmt.Errorf("invalid lat,lon point %q", s)
+	}
+
+	lat, err := strconv.ParseFloat(strings.TrimSpace(parts[0]), 64)
+	if err != nil {
+		return wrapErr(err, "parse latitude")
+	}
+
+	lon, err := strconv.ParseFloat(strings.TrimSpace(parts[1]), 64)
+	if err != nil {
+		return wrapErr(err, "parse longitude")
+	}
+
+	ll.Lat, ll.Lon = lat, lon
+	return nil
+}
+
+// GeoDist returns the geodist function, the distance in kilometers between
+// the point and the spatial field, which can be used in sort and fields
+// e.g. Sort(GeoDist("store", pt) + " asc") and Fields("dist:" + GeoDist("store", pt))
+//
+// Refer to https://solr.apache.org/guide/8_8/spatial-search.html#geodist
+func GeoDist(sfield string, pt LatLon) string {
+	return fmt.Sprintf("geodist(%s,%s,%s)", sfield, formatFloat(pt.Lat), formatFloat(pt.Lon))
+}
+
+// Polygon is a polygon shape builder
+type Polygon struct {
+	points []LatLon
+}
+
+// NewPolygon takes the points of the exterior ring in
+// counter-clockwise order and returns a new Polygon.
+// The ring is closed automatically.
+func NewPolygon(points ...LatLon) *Polygon {
+	return &Polygon{points: points}
+}
+
+// ring returns the closed ring of the polygon
+func (p *Polygon) ring() []LatLon {
+	if len(p.points) > 0 && p.points[0] != p.points[len(p.points)-1] {
+		return append(append([]LatLon{}, p.points...), p.points[0])
+	}
+
+	return p.points
+}
+
+// WKT returns the polygon in Well-Known Text format
+func (p *Polygon) WKT() string {
+	coords := []string{}
+	for _, pt := range p.ring() {
+		coords = append(coords, formatFloat(pt.Lon)+" "+formatFloat(pt.Lat))
+	}
+
+	return fmt.Sprintf("POLYGON((%s))", strings.Join(coords, ", "))
+}
+
+// GeoJSON returns the polygon in GeoJSON format
+func (p *Polygon) GeoJSON() string {
+	coords := []string{}
+	for _, pt := range p.ring() {
+		coords = append(coords, fmt.Sprintf("[%s,%s]", formatFloat(pt.Lon), formatFloat(pt.Lat)))
+	}
+
+	return fmt.Sprintf(`{"type":"Polygon","coordinates":[[%s]]}`, strings.Join(coords, ","))
+}
+
+// Intersects returns a query that matches the documents whose shape in
+// the (RPT) field intersects the shape, which is in WKT or GeoJSON format
+//
+// Refer to https://solr.apache.org/guide/8_8/spatial-search.html#rpt
+func Intersects(field, shape string) string {
+	return spatialPredicate("Intersects", field, shape)
+}
+
+// IsWithin returns a query that matches the documents whose shape in the
+// (RPT) field is within the shape, which is in WKT or GeoJSON format
+//
+// Refer to https://solr.apache.org/guide/8_8/spatial-search.html#rpt
+func IsWithin(field, shape string) string {
+	return spatialPredicate("IsWithin", field, shape)
+}
+
+// Contains returns a query that matches the documents whose shape in the
+// (RPT) field contains the shape, which is in WKT or GeoJSON format
+//
+// Refer to https://solr.apache.org/guide/8_8/spatial-search.html#rpt
+func Contains(field, shape string) string {
+	return spatialPredicate("Contains", field, shape)
+}
+
+// IsDisjointTo returns a query that matches the documents whose shape in
+// the (RPT) field is disjoint to the shape, which is in WKT or GeoJSON format
+//
+// Refer to https://solr.apache.org/guide/8_8/spatial-search.html#rpt
+func IsDisjointTo(field, shape string) string {
+	return spatialPredicate("IsDisjointTo", field, shape)
+}
+
+func spatialPredicate(predicate, field, shape string) string {
+	return fmt.Sprintf("{!field f=%s}%s(%s)", field, predicate, shape)
+}
+
+// formatFloat formats the float using the fewest digits necessary
+func formatFloat(f float64) string {
+	return strconv.FormatFloat(f, 'f', -1, 64)
+}
@@ -0,0 +1,79 @@
+package solr_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/stevenferrer/solr-go"
+)
+
+func TestLatLon(t *testing.T) {
+	t.Run("marshal", func(t *testing.T) {
+		b, err := json.Marshal(solr.M{"store": solr.LatLon{Lat: 45.15, Lon: -93.85}})
+		require.NoError(t, err)
+		assert.JSONEq(t, `{"store":"45.15,-93.85"}`, string(b))
+	})
+
+	t.Run("unmarshal", func(t *testing.T) {
+		var doc struct {
+			Store solr.LatLon `json:"store"`
+		}
+		err := json.Unmarshal([]byte(`{"store":"45.15, -93.85"}`), &doc)
+		require.NoError(t, err)
+		assert.Equal(t, solr.LatLon{Lat: 45.15, Lon: -93.85}, doc.Store)
+
+		err = json.Unmarshal([]byte(`{"store":"45.15"}`), &doc)
+		assert.Error(t, err)
+
+		err = json.Unmarshal([]byte(`{"store":"a,b"}`), &doc)
+		assert.Error(t, err)
+	})
+}
+
+func TestGeoDist(t *testing.T) {
+	pt := solr.LatLon{Lat: 45.15, Lon: -93.85}
+	geodist := solr.GeoDist("store", pt)
+	assert.Equal(t, "geodist(store,45.15,-93.85)", geodist)
+
+	got := solr.NewQuery(solr.NewStandardQueryParser().Query("*:*").BuildParser()).
+		Filters(solr.NewGeofiltQueryParser().Sfield("store").Pt(pt).D(5).BuildParser()).
+		Sort(geodist+" asc").
+		Fields("id", "dist:"+geodist).
+		BuildQuery()
+
+	assert.Equal(t, "geodist(store,45.15,-93.85) asc", got["sort"])
+	assert.Equal(t, []string{"id", "dist:geodist(store,45.15,-93.85)"}, got["fields"])
+}
+
+func TestPolygon(t *testing.T) {
+	polygon := solr.NewPolygon(
+		solr.LatLon{Lat: 10, Lon: 30},
+		solr.LatLon{Lat: 40, Lon: 40},
+		solr.LatLon{Lat: 40, Lon: 20},
+		solr.LatLon{Lat: 20, Lon: 10},
+	)
+
+	wkt := polygon.WKT()
+	assert.Equal(t, "POLYGON((30 10, 40 40, 20 40, 10 20, 30 10))", wkt)
+
+	geoJSON := polygon.GeoJSON()
+	assert.JSONEq(t, `{"type":"Polygon","coordinates":[[[30,10],[40,40],[20,40],[10,20],[30,10]]]}`, geoJSON)
+
+	// already closed ring
+	closed := solr.NewPolygon(
+		solr.LatLon{Lat: 0, Lon: 0},
+		solr.LatLon{Lat: 0, Lon: 1},
+		solr.LatLon{Lat: 1, Lon: 1},
+		solr.LatLon{Lat: 0, Lon: 0},
+	)
+	assert.Equal(t, "POLYGON((0 0, 1 0, 1 1, 0 0))", closed.WKT())
+
+	assert.Equal(t, "{!field f=geo}Intersects(POLYGON((30 10, 40 40, 20 40, 10 20, 30 10)))",
+		solr.Intersects("geo", wkt))
+	assert.Equal(t, "{!field f=geo}IsWithin("+geoJSON+")", solr.IsWithin("geo", geoJSON))
+	assert.Equal(t, "{!field f=geo}Contains(POINT(1 2))", solr.Contains("geo", "POINT(1 2)"))
+	assert.Equal(t, "{!field f=geo}IsDisjointTo(POINT(1 2))", solr.IsDisjointTo("geo", "POINT(1 2)"))
+}
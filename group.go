@@ -0,0 +1,227 @@
+package solr
+
+// Group is the result grouping param builder. The groups
+// are sorted by the sort of the Query (Query.Sort).
+//
+// Refer to https://solr.apache.org/guide/8_8/result-grouping.html
+type Group struct {
+	fields   []string
+	queries  []string
+	funcs    []string
+	limit    int
+	offset   int
+	sort     string
+	ngroups  bool
+	format   string
+	main     bool
+	truncate bool
+	facet    bool
+}
+
+// NewGroup returns a new Group
+func NewGroup() *Group {
+	return &Group{}
+}
+
+// Fields sets the fields to group by (group.field)
+func (g *Group) Fields(fields ...string) *Group {
+	g.fields = fields
+	return g
+}
+
+// Queries sets the queries to group by (group.query)
+func (g *Group) Queries(queries ...string) *Group {
+	g.queries = queries
+	return g
+}
+
+// Funcs sets the function queries to group by (group.func)
+func (g *Group) Funcs(funcs ...string) *Group {
+	g.funcs = funcs
+	return g
+}
+
+// Limit sets the number of documents to return per group (group.limit)
+func (g *Group) Limit(limit int) *Group {
+	g.limit = limit
+	return g
+}
+
+// Offset sets the offset of the documents in each group (group.offset)
+func (g *Group) Offset(offset int) *Group {
+	g.offset = offset
+	return g
+}
+
+// Sort sets the sort of the documents within each group (group.sort)
+func (g *Group) Sort(sort string) *Group {
+	g.sort = sort
+	return g
+}
+
+// NGroups if true, returns the number of groups (group.ngroups)
+func (g *Group) NGroups(ngroups bool) *Group {
+	g.ngroups = ngroups
+	return g
+}
+
+// Format sets the format of the grouped documents, grouped or simple
+func (g *Group) Format(format string) *Group {
+	g.format = format
+	return g
+}
+
+// Main if true, returns the documents of the first
+// grouping command as the main result list
+func (g *Group) Main(main bool) *Group {
+	g.main = main
+	return g
+}
+
+// Truncate if true, the facet counts are based
+// on the most relevant document of each group
+func (g *Group) Truncate(truncate bool) *Group {
+	g.truncate = truncate
+	return g
+}
+
+// Facet if true, computes grouped facets for the field facets
+func (g *Group) Facet(facet bool) *Group {
+	g.facet = facet
+	return g
+}
+
+// BuildParams builds the grouping params
+func (g *Group) BuildParams() M {
+	m := M{"group": true}
+
+	if len(g.fields) > 0 {
+		m["group.field"] = g.fields
+	}
+
+	if len(g.queries) > 0 {
+		m["group.query"] = g.queries
+	}
+
+	if len(g.funcs) > 0 {
+		m["group.func"] = g.funcs
+	}
+
+	if g.limit != 0 {
+		m["group.limit"] = g.limit
+	}
+
+	if g.offset > 0 {
+		m["group.offset"] = g.offset
+	}
+
+	if g.sort != "" {
+		m["group.sort"] = g.sort
+	}
+
+	if g.ngroups {
+		m["group.ngroups"] = true
+	}
+
+	if g.format != "" {
+		m["group.format"] = g.format
+	}
+
+	if g.main {
+		m["group.main"] = true
+	}
+
+	if g.truncate {
+		m["group.truncate"] = true
+	}
+
+	if g.facet {
+		m["group.facet"] = true
+	}
+
+	return m
+}
+
+// GroupResult is the result of a grouping command
+type GroupResult struct {
+	// Matches is the number of documents that matched the query
+	Matches int `json:"matches"`
+	// NGroups is the number of groups, only available with group.ngroups
+	NGroups int `json:"ngroups"`
+	// Groups is the list of groups of a group.field or group.func command
+	Groups []GroupValue `json:"groups"`
+	// DocList is the documents of a group.query command,
+	// or of any command with group.format=simple
+	DocList *QueryResponseBody `json:"doclist"`
+}
+
+// GroupValue is a group and its documents
+type GroupValue struct {
+	// GroupValue is the value of the group, nil for documents without a value
+	GroupValue interface{} `json:"groupValue"`
+	// DocList is the documents in the group
+	DocList QueryResponseBody `json:"doclist"`
+}
+
+// Expand is the expand component param builder, used to
+// return the documents of the groups collapsed by collapse
+//
+// Refer to https://solr.apache.org/guide/8_8/collapse-and-expand-results.html#expand-component
+type Expand struct {
+	sort    string
+	rows    int
+	q       string
+	filters []string
+}
+
+// NewExpand returns a new Expand
+func NewExpand() *Expand {
+	return &Expand{}
+}
+
+// Sort sets the sort of the expanded documents (expand.sort)
+func (e *Expand) Sort(sort string) *Expand {
+	e.sort = sort
+	return e
+}
+
+// Rows sets the number of documents to return per group (expand.rows)
+func (e *Expand) Rows(rows int) *Expand {
+	e.rows = rows
+	return e
+}
+
+// Query overrides the query used to expand the groups (expand.q)
+func (e *Expand) Query(q string) *Expand {
+	e.q = q
+	return e
+}
+
+// Filters overrides the filters used to expand the groups (expand.fq)
+func (e *Expand) Filters(filters ...string) *Expand {
+	e.filters = filters
+	return e
+}
+
+// BuildParams builds the expand params
+func (e *Expand) BuildParams() M {
+	m := M{"expand": true}
+
+	if e.sort != "" {
+		m["expand.sort"] = e.sort
+	}
+
+	if e.rows != 0 {
+		m["expand.rows"] = e.rows
+	}
+
+	if e.q != "" {
+		m["expand.q"] = e.q
+	}
+
+	if len(e.filters) > 0 {
+		m["expand.fq"] = e.filters
+	}
+
+	return m
+}
@@ -0,0 +1,115 @@
+package solr_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/stevenferrer/solr-go"
+)
+
+func TestGroup(t *testing.T) {
+	got := solr.NewGroup().
+		Fields("manu_exact").
+		Queries("price:[0 TO 99.99]").
+		Funcs("floor(price)").
+		Limit(3).
+		Offset(1).
+		Sort("price asc").
+		NGroups(true).
+		Format("grouped").
+		Main(true).
+		Truncate(true).
+		Facet(true).
+		BuildParams()
+
+	expect := solr.M{
+		"group":          true,
+		"group.field":    []string{"manu_exact"},
+		"group.query":    []string{"price:[0 TO 99.99]"},
+		"group.func":     []string{"floor(price)"},
+		"group.limit":    3,
+		"group.offset":   1,
+		"group.sort":     "price asc",
+		"group.ngroups":  true,
+		"group.format":   "grouped",
+		"group.main":     true,
+		"group.truncate": true,
+		"group.facet":    true,
+	}
+	assert.Equal(t, expect, got)
+
+	query := solr.NewQuery("memory").
+		Group(solr.NewGroup().Fields("manu_exact")).
+		BuildQuery()
+	assert.Equal(t, solr.M{"group": true, "group.field": []string{"manu_exact"}}, query["params"])
+}
+
+func TestExpand(t *testing.T) {
+	got := solr.NewExpand().BuildParams()
+	assert.Equal(t, solr.M{"expand": true}, got)
+
+	got = solr.NewExpand().
+		Sort("price asc").
+		Rows(5).
+		Query("*:*").
+		Filters("inStock:true").
+		BuildParams()
+	expect := solr.M{
+		"expand":      true,
+		"expand.sort": "price asc",
+		"expand.rows": 5,
+		"expand.q":    "*:*",
+		"expand.fq":   []string{"inStock:true"},
+	}
+	assert.Equal(t, expect, got)
+
+	query := solr.NewQuery("shirt").
+		Filters(solr.NewCollapseQueryParser().Field("group_id").BuildParser()).
+		Expand(solr.NewExpand().Rows(2)).
+		BuildQuery()
+	assert.Equal(t, []string{"{!collapse field=group_id}"}, query["filter"])
+	assert.Equal(t, solr.M{"expand": true, "expand.rows": 2}, query["params"])
+}
+
+func TestGroupedResponse(t *testing.T) {
+	body := `{
+		"grouped": {
+			"manu_exact": {
+				"matches": 5,
+				"ngroups": 2,
+				"groups": [
+					{"groupValue": "Apple", "doclist": {"numFound": 3, "start": 0, "docs": [{"id": "1"}]}},
+					{"groupValue": null, "doclist": {"numFound": 2, "start": 0, "docs": [{"id": "4"}]}}
+				]
+			},
+			"price:[0 TO 99.99]": {
+				"matches": 5,
+				"doclist": {"numFound": 1, "start": 0, "docs": [{"id": "2"}]}
+			}
+		},
+		"expanded": {
+			"variant-1": {"numFound": 2, "start": 0, "docs": [{"id": "5"}, {"id": "6"}]}
+		}
+	}`
+
+	var resp solr.QueryResponse
+	err := json.Unmarshal([]byte(body), &resp)
+	require.NoError(t, err)
+
+	manu := resp.Grouped["manu_exact"]
+	assert.Equal(t, 5, manu.Matches)
+	assert.Equal(t, 2, manu.NGroups)
+	require.Len(t, manu.Groups, 2)
+	assert.Equal(t, "Apple", manu.Groups[0].GroupValue)
+	assert.Equal(t, 3, manu.Groups[0].DocList.NumFound)
+	assert.Nil(t, manu.Groups[1].GroupValue)
+
+	price := resp.Grouped["price:[0 TO 99.99]"]
+	require.NotNil(t, price.DocList)
+	assert.Equal(t, []solr.M{{"id": "2"}}, price.DocList.Documents)
+
+	assert.Len(t, resp.Expanded["variant-1"].Documents, 2)
+}
@@ -0,0 +1,164 @@
+package solr
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Highlight is the highlighting param builder
+//
+// Refer to https://solr.apache.org/guide/8_8/highlighting.html
+type Highlight struct {
+	method            string
+	fields            []string
+	snippets          int
+	fragsize          int
+	tagPre            string
+	tagPost           string
+	requireFieldMatch bool
+	q                 string
+	perField          map[string]M
+}
+
+// NewHighlight returns a new Highlight
+func NewHighlight() *Highlight {
+	return &Highlight{perField: map[string]M{}}
+}
+
+// Method sets the highlighting implementation
+// (unified, original or fastVector)
+func (h *Highlight) Method(method string) *Highlight {
+	h.method = method
+	return h
+}
+
+// Fields sets the fields to highlight (hl.fl)
+func (h *Highlight) Fields(fields ...string) *Highlight {
+	h.fields = fields
+	return h
+}
+
+// Snippets sets the maximum number of snippets per field
+func (h *Highlight) Snippets(snippets int) *Highlight {
+	h.snippets = snippets
+	return h
+}
+
+// Fragsize sets the approximate number of characters of
+// each snippet, 0 means the whole field value is used
+func (h *Highlight) Fragsize(fragsize int) *Highlight {
+	h.fragsize = fragsize
+	return h
+}
+
+// Tags sets the text to insert before (hl.tag.pre)
+// and after (hl.tag.post) the highlighted terms
+func (h *Highlight) Tags(pre, post string) *Highlight {
+	h.tagPre = pre
+	h.tagPost = post
+	return h
+}
+
+// RequireFieldMatch if true, only highlights the terms of
+// the fields that matched the query
+func (h *Highlight) RequireFieldMatch(requireFieldMatch bool) *Highlight {
+	h.requireFieldMatch = requireFieldMatch
+	return h
+}
+
+// Query overrides the query to highlight (hl.q)
+func (h *Highlight) Query(q string) *Highlight {
+	h.q = q
+	return h
+}
+
+// PerField sets the highlighting params for a specific field
+// e.g. PerField("name", M{"snippets": 3}) sets f.name.hl.snippets=3
+func (h *Highlight) PerField(field string, params M) *Highlight {
+	h.perField[field] = params
+	return h
+}
+
+// BuildParams builds the highlighting params
+func (h *Highlight) BuildParams() M {
+	m := M{"hl": true}
+
+	if h.method != "" {
+		m["hl.method"] = h.method
+	}
+
+	if len(h.fields) > 0 {
+		m["hl.fl"] = strings.Join(h.fields, ",")
+	}
+
+	if h.snippets > 0 {
+		m["hl.snippets"] = h.snippets
+	}
+
+	if h.fragsize > 0 {
+		m["hl.fragsize"] = h.fragsize
+	}
+
+	if h.tagPre != "" {
+		m["hl.tag.pre"] = h.tagPre
+	}
+
+	if h.tagPost != "" {
+		m["hl.tag.post"] = h.tagPost
+	}
+
+	if h.requireFieldMatch {
+		m["hl.requireFieldMatch"] = true
+	}
+
+	if h.q != "" {
+		m["hl.q"] = h.q
+	}
+
+	for field, params := range h.perField {
+		for k, v := range params {
+			m[fmt.Sprintf("f.%s.hl.%s", field, k)] = v
+		}
+	}
+
+	return m
+}
+
+// Highlighting is the highlighting section of the
+// query response, keyed by document id then by field
+type Highlighting map[string]map[string][]string
+
+// MergeHighlighting returns a copy of the documents with the highlighted
+// field values replaced by their snippets. Multi-valued fields are replaced
+// with the list of snippets, while single-valued fields are replaced with
+// the snippets joined by " ... ". uniqueKey is the unique key field name.
+func MergeHighlighting(docs []M, hl Highlighting, uniqueKey string) []M {
+	merged := make([]M, 0, len(docs))
+	for _, doc := range docs {
+		newDoc := M{}
+		for k, v := range doc {
+			newDoc[k] = v
+		}
+
+		for field, snippets := range hl[fmt.Sprint(doc[uniqueKey])] {
+			if len(snippets) == 0 {
+				continue
+			}
+
+			if _, ok := doc[field].([]interface{}); ok {
+				values := make([]interface{}, 0, len(snippets))
+				for _, snippet := range snippets {
+					values = append(values, snippet)
+				}
+				newDoc[field] = values
+				continue
+			}
+
+			newDoc[field] = strings.Join(snippets, " ... ")
+		}
+
+		merged = append(merged, newDoc)
+	}
+
+	return merged
+}
@@ -0,0 +1,92 @@
+package solr_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/stevenferrer/solr-go"
+)
+
+func TestHighlight(t *testing.T) {
+	got := solr.NewHighlight().BuildParams()
+	assert.Equal(t, solr.M{"hl": true}, got)
+
+	got = solr.NewHighlight().
+		Method("unified").
+		Fields("name", "features").
+		Snippets(2).
+		Fragsize(100).
+		Tags("<em>", "</em>").
+		RequireFieldMatch(true).
+		Query("name:ipod").
+		PerField("features", solr.M{"snippets": 5, "fragsize": 0}).
+		BuildParams()
+
+	expect := solr.M{
+		"hl":                     true,
+		"hl.method":              "unified",
+		"hl.fl":                  "name,features",
+		"hl.snippets":            2,
+		"hl.fragsize":            100,
+		"hl.tag.pre":             "<em>",
+		"hl.tag.post":            "</em>",
+		"hl.requireFieldMatch":   true,
+		"hl.q":                   "name:ipod",
+		"f.features.hl.snippets": 5,
+		"f.features.hl.fragsize": 0,
+	}
+	assert.Equal(t, expect, got)
+
+	query := solr.NewQuery("name:ipod").
+		Highlight(solr.NewHighlight().Fields("name")).
+		BuildQuery()
+	assert.Equal(t, solr.M{"hl": true, "hl.fl": "name"}, query["params"])
+}
+
+func TestMergeHighlighting(t *testing.T) {
+	body := `{
+		"response": {"numFound": 2, "docs": [
+			{"id": "1", "name": "Apple iPod", "features": ["great sound", "ipod mini"]},
+			{"id": "2", "name": "iPod dock"}
+		]},
+		"highlighting": {
+			"1": {"name": ["Apple <em>iPod</em>"], "features": ["<em>ipod</em> mini"]},
+			"2": {"name": ["<em>iPod</em>", "<em>iPod</em> dock"], "features": []}
+		}
+	}`
+
+	var resp solr.QueryResponse
+	err := json.Unmarshal([]byte(body), &resp)
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"Apple <em>iPod</em>"}, resp.Highlighting["1"]["name"])
+
+	merged := solr.MergeHighlighting(resp.Response.Documents, resp.Highlighting, "id")
+	expect := []solr.M{
+		{"id": "1", "name": "Apple <em>iPod</em>", "features": []interface{}{"<em>ipod</em> mini"}},
+		{"id": "2", "name": "<em>iPod</em> ... <em>iPod</em> dock"},
+	}
+	assert.Equal(t, expect, merged)
+
+	// original documents are left untouched
+	assert.Equal(t, "Apple iPod", resp.Response.Documents[0]["name"])
+
+	type product struct {
+		ID       string   `json:"id"`
+		Name     string   `json:"name"`
+		Features []string `json:"features"`
+	}
+
+	var products []product
+	err = resp.DecodeDocumentsWithHighlighting(&products, "id")
+	require.NoError(t, err)
+
+	expectProducts := []product{
+		{ID: "1", Name: "Apple <em>iPod</em>", Features: []string{"<em>ipod</em> mini"}},
+		{ID: "2", Name: "<em>iPod</em> ... <em>iPod</em> dock"},
+	}
+	assert.Equal(t, expectProducts, products)
+}
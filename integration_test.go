@@ -166,7 +166,7 @@ func newTestRunner(client solr.Client, collection string) func(t *testing.T) {
 		_, err = client.Update(ctx, collection, solr.JSON, buf)
 		require.NoError(t, err, "indexing data should not eror")
 
-		err = client.Commit(ctx, collection)
+		_, err = client.Commit(ctx, collection, nil)
 		require.NoError(t, err, "commmit should not error")
 
 		// Query
@@ -8,7 +8,10 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"net/url"
+	"strconv"
 	"strings"
+	"time"
 )
 
 // JSONClient is a client for interacting with Solr via JSON API
@@ -134,6 +137,55 @@ func (c *JSONClient) UnloadCore(ctx context.Context, params *CoreParams) error {
 //
 // Refer to https://solr.apache.org/guide/8_8/json-request-api.html
 func (c *JSONClient) Query(ctx context.Context, collection string, query *Query) (*QueryResponse, error) {
+	httpResp, err := c.sendQuery(ctx, collection, query)
+	if err != nil {
+		return nil, err
+	}
+
+	var resp QueryResponse
+	err = readResponse(httpResp, &resp)
+	if err != nil {
+		return nil, wrapErr(err, "read response")
+	}
+
+	return &resp, nil
+}
+
+// QueryStream is the same as Query but decodes the response as it is read,
+// calling fn for each document instead of collecting them into the response,
+// so large pages are processed with one document in memory at a time. The
+// returned response holds everything else e.g. the response header, numFound
+// and facets. Returning an error from fn stops the decoding and returns the error.
+//
+// Refer to https://solr.apache.org/guide/8_8/json-request-api.html
+func (c *JSONClient) QueryStream(ctx context.Context, collection string, query *Query,
+	fn func(doc M) error) (*QueryResponse, error) {
+	if fn == nil {
+		return nil, errors.New("fn is required")
+	}
+
+	httpResp, err := c.sendQuery(ctx, collection, query)
+	if err != nil {
+		return nil, err
+	}
+	defer httpResp.Body.Close()
+
+	var resp QueryResponse
+	if httpResp.StatusCode > http.StatusOK ||
+		strings.Contains(httpResp.Header.Get("content-type"), "text/html") {
+		// readResponse always returns an error for these responses
+		return nil, wrapErr(readResponse(httpResp, &resp), "read response")
+	}
+
+	err = decodeQueryStream(httpResp.Body, &resp, fn)
+	if err != nil {
+		return nil, wrapErr(err, "read response")
+	}
+
+	return &resp, nil
+}
+
+func (c *JSONClient) sendQuery(ctx context.Context, collection string, query *Query) (*http.Response, error) {
 	buf := &bytes.Buffer{}
 	err := json.NewEncoder(buf).Encode(query.BuildQuery())
 	if err != nil {
@@ -146,20 +198,53 @@ func (c *JSONClient) Query(ctx context.Context, collection string, query *Query)
 		return nil, wrapErr(err, "send request")
 	}
 
-	var resp QueryResponse
-	err = readResponse(httpResp, &resp)
+	return httpResp, nil
+}
+
+// QueryWithCollation sends the query and, if it has no results, re-runs it
+// with the best spellcheck collation in place of the main query. The query
+// should enable spellcheck with collate. It returns the collation that was
+// used, which is empty if the query was not re-run.
+//
+// Refer to https://solr.apache.org/guide/8_8/spell-checking.html#spellcheck-collate
+func (c *JSONClient) QueryWithCollation(ctx context.Context, collection string, query *Query) (*QueryResponse, string, error) {
+	resp, err := c.Query(ctx, collection, query)
 	if err != nil {
-		return nil, wrapErr(err, "read response")
+		return nil, "", err
 	}
 
-	return &resp, nil
+	collation := resp.Spellcheck.BestCollation()
+	if resp.Response.NumFound > 0 || collation == "" {
+		return resp, "", nil
+	}
+
+	resp, err = c.Query(ctx, collection, query.withQuery(collation))
+	if err != nil {
+		return nil, "", wrapErr(err, "query with collation")
+	}
+
+	return resp, collation, nil
 }
 
 // Update can be used to add, update, or delete a document from the index.
 //
 // Refer to https://solr.apache.org/guide/8_8/uploading-data-with-index-handlers.html
 func (c *JSONClient) Update(ctx context.Context, collection string, mimeType MimeType, body io.Reader) (*UpdateResponse, error) {
+	return c.UpdateWithParams(ctx, collection, mimeType, body, nil)
+}
+
+// UpdateWithParams is the same as Update but accepts additional update params.
+//
+// Refer to https://solr.apache.org/guide/8_8/uploading-data-with-index-handlers.html
+func (c *JSONClient) UpdateWithParams(ctx context.Context, collection string, mimeType MimeType,
+	body io.Reader, params *UpdateParams) (*UpdateResponse, error) {
 	urlStr := fmt.Sprintf("%s/solr/%s/update", c.baseURL, collection)
+	if params != nil {
+		if p := params.BuildParams(); p != "" {
+			urlStr += "?" + p
+		}
+	}
+
 	httpResp, err := c.reqSender.SendRequest(ctx, http.MethodPost, urlStr, mimeType.String(), body)
 	if err != nil {
 		return nil, wrapErr(err, "send request")
@@ -174,113 +259,545 @@ func (c *JSONClient) Update(ctx context.Context, collection string, mimeType Mim
 	return &resp, nil
 }
 
-// Commit commits the last update.
-func (c *JSONClient) Commit(ctx context.Context, collection string) error {
-	urlStr := fmt.Sprintf("%s/solr/%s/update?commit=true", c.baseURL, collection)
+// maxUpdateAttempts is the maximum number of attempts
+// of UpdateWithRetry when the update conflicts
+const maxUpdateAttempts = 5
+
+// updateRetryBackoff is the wait before the first retry of
+// UpdateWithRetry, it doubles on each following retry
+const updateRetryBackoff = 10 * time.Millisecond
+
+// UpdateWithRetry does a safe read-modify-write of a document. It fetches the
+// latest version of the document via real-time get, applies fn and sends the
+// result with a _version_ constraint, retrying with backoff when the update
+// conflicts with a concurrent write. fn receives nil if the document does not exist yet,
+// and must not return a nil document.
+//
+// Refer to https://solr.apache.org/guide/8_8/updating-parts-of-documents.html#optimistic-concurrency
+func (c *JSONClient) UpdateWithRetry(ctx context.Context, collection, id string,
+	fn func(doc M) (M, error)) (*UpdateResponse, error) {
+	backoff := updateRetryBackoff
+	for attempt := 1; ; attempt++ {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		getResp, err := c.Get(ctx, collection, id)
+		if err != nil {
+			return nil, wrapErr(err, "real-time get")
+		}
+
+		var doc M
+		version := VersionMustNotExist
+		if len(getResp.Documents) > 0 {
+			doc = getResp.Documents[0]
+			version, err = toVersion(doc["_version_"])
+			if err != nil {
+				return nil, wrapErr(err, "parse document version")
+			}
+		}
+
+		newDoc, err := fn(doc)
+		if err != nil {
+			return nil, wrapErr(err, "apply update")
+		}
+
+		if newDoc == nil {
+			return nil, errors.New("apply update: fn returned a nil document")
+		}
+
+		b, err := json.Marshal(NewUpdateCommands().AddWithVersion(newDoc, version))
+		if err != nil {
+			return nil, wrapErr(err, "encode request body")
+		}
+
+		resp, err := c.UpdateWithParams(ctx, collection, JSON,
+			bytes.NewReader(b), NewUpdateParams().Versions(true))
+		if err == nil {
+			return resp, nil
+		}
+
+		if !IsVersionConflict(err) || attempt >= maxUpdateAttempts {
+			return nil, err
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(backoff):
+		}
+		backoff *= 2
+	}
+}
+
+// Get fetches the latest version of the documents from the real-time get handler.
+//
+// Refer to https://solr.apache.org/guide/8_8/realtime-get.html
+func (c *JSONClient) Get(ctx context.Context, collection string, ids ...string) (*GetResponse, error) {
+	return c.GetWithParams(ctx, collection, NewRealTimeGetParams(ids...))
+}
+
+// GetWithParams is the same as Get but accepts additional params.
+//
+// Refer to https://solr.apache.org/guide/8_8/realtime-get.html
+func (c *JSONClient) GetWithParams(ctx context.Context, collection string, params *RealTimeGetParams) (*GetResponse, error) {
+	urlStr := fmt.Sprintf("%s/solr/%s/get?%s", c.baseURL, collection, params.BuildParams())
 	httpResp, err := c.reqSender.SendRequest(ctx, http.MethodGet, urlStr, JSON.String(), nil)
 	if err != nil {
-		return wrapErr(err, "send request")
+		return nil, wrapErr(err, "send request")
+	}
+
+	var resp GetResponse
+	err = readResponse(httpResp, &resp)
+	if err != nil {
+		return nil, wrapErr(err, "read response")
+	}
+
+	return &resp, nil
+}
+
+// Commit commits the last update. If opts is nil, a hard commit with
+// the default options is issued. Soft commits and optimize are
+// configured through CommitOptions.
+//
+// Refer to https://solr.apache.org/guide/8_8/uploading-data-with-index-handlers.html#commit-and-optimize-during-updates
+func (c *JSONClient) Commit(ctx context.Context, collection string, opts *CommitOptions) (*UpdateResponse, error) {
+	if opts == nil {
+		opts = NewCommitOptions()
+	}
+
+	urlStr := fmt.Sprintf("%s/solr/%s/update?%s", c.baseURL, collection, opts.BuildParams())
+	httpResp, err := c.reqSender.SendRequest(ctx, http.MethodGet, urlStr, JSON.String(), nil)
+	if err != nil {
+		return nil, wrapErr(err, "send request")
 	}
 
 	var resp UpdateResponse
 	err = readResponse(httpResp, &resp)
 	if err != nil {
-		return wrapErr(err, "read response")
+		return nil, wrapErr(err, "read response")
 	}
 
-	return nil
+	return &resp, nil
+}
+
+// GetSchema returns the whole schema.
+//
+// Refer to https://solr.apache.org/guide/8_8/schema-api.html#retrieve-the-entire-schema
+func (c *JSONClient) GetSchema(ctx context.Context, collection string) (*Schema, error) {
+	resp, err := c.readSchema(ctx, collection, "", nil)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.Schema == nil {
+		return nil, errors.New("schema not found in response")
+	}
+
+	return resp.Schema, nil
+}
+
+// ListFields returns the fields. If includeDynamic is true, the dynamic fields
+// that match the fields used in the documents are included. If showDefaults
+// is true, the properties inherited from the field types are included.
+//
+// Refer to https://solr.apache.org/guide/8_8/schema-api.html#list-fields
+func (c *JSONClient) ListFields(ctx context.Context, collection string, includeDynamic, showDefaults bool) ([]Field, error) {
+	params := url.Values{
+		"includeDynamic": {strconv.FormatBool(includeDynamic)},
+		"showDefaults":   {strconv.FormatBool(showDefaults)},
+	}
+	resp, err := c.readSchema(ctx, collection, "fields", params)
+	if err != nil {
+		return nil, err
+	}
+
+	return resp.Fields, nil
+}
+
+// GetField returns the field. If showDefaults is true, the
+// properties inherited from the field type are included.
+//
+// Refer to https://solr.apache.org/guide/8_8/schema-api.html#list-fields
+func (c *JSONClient) GetField(ctx context.Context, collection, name string, showDefaults bool) (*Field, error) {
+	params := url.Values{"showDefaults": {strconv.FormatBool(showDefaults)}}
+	resp, err := c.readSchema(ctx, collection, "fields/"+url.PathEscape(name), params)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.Field == nil {
+		return nil, fmt.Errorf("field %q not found in response", name)
+	}
+
+	return resp.Field, nil
+}
+
+// ListDynamicFields returns the dynamic field rules. If showDefaults is
+// true, the properties inherited from the field types are included.
+//
+// Refer to https://solr.apache.org/guide/8_8/schema-api.html#list-dynamic-fields
+func (c *JSONClient) ListDynamicFields(ctx context.Context, collection string, showDefaults bool) ([]Field, error) {
+	params := url.Values{"showDefaults": {strconv.FormatBool(showDefaults)}}
+	resp, err := c.readSchema(ctx, collection, "dynamicfields", params)
+	if err != nil {
+		return nil, err
+	}
+
+	return resp.DynamicFields, nil
+}
+
+// ListFieldTypes returns the field types. If showDefaults is
+// true, the default properties of the field types are included.
+//
+// Refer to https://solr.apache.org/guide/8_8/schema-api.html#list-field-types
+func (c *JSONClient) ListFieldTypes(ctx context.Context, collection string, showDefaults bool) ([]FieldType, error) {
+	params := url.Values{"showDefaults": {strconv.FormatBool(showDefaults)}}
+	resp, err := c.readSchema(ctx, collection, "fieldtypes", params)
+	if err != nil {
+		return nil, err
+	}
+
+	return resp.FieldTypes, nil
+}
+
+// ListCopyFields returns the copy field rules.
+//
+// Refer to https://solr.apache.org/guide/8_8/schema-api.html#list-copy-fields
+func (c *JSONClient) ListCopyFields(ctx context.Context, collection string) ([]CopyField, error) {
+	resp, err := c.readSchema(ctx, collection, "copyfields", nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return resp.CopyFields, nil
+}
+
+// GetUniqueKey returns the unique key field name.
+//
+// Refer to https://solr.apache.org/guide/8_8/schema-api.html#show-unique-key
+func (c *JSONClient) GetUniqueKey(ctx context.Context, collection string) (string, error) {
+	resp, err := c.readSchema(ctx, collection, "uniquekey", nil)
+	if err != nil {
+		return "", err
+	}
+
+	return resp.UniqueKey, nil
+}
+
+// GetSchemaVersion returns the schema version.
+//
+// Refer to https://solr.apache.org/guide/8_8/schema-api.html#show-the-schema-version
+func (c *JSONClient) GetSchemaVersion(ctx context.Context, collection string) (float64, error) {
+	resp, err := c.readSchema(ctx, collection, "version", nil)
+	if err != nil {
+		return 0, err
+	}
+
+	return resp.Version, nil
+}
+
+// GetSimilarity returns the global similarity.
+//
+// Refer to https://solr.apache.org/guide/8_8/schema-api.html#show-global-similarity
+func (c *JSONClient) GetSimilarity(ctx context.Context, collection string) (*Similarity, error) {
+	resp, err := c.readSchema(ctx, collection, "similarity", nil)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.Similarity == nil {
+		return nil, errors.New("similarity not found in response")
+	}
+
+	return resp.Similarity, nil
+}
+
+// readSchema sends a request to the schema read API, path is
+// the resource under the schema endpoint e.g. fields
+func (c *JSONClient) readSchema(ctx context.Context, collection, path string, params url.Values) (*schemaResponse, error) {
+	urlStr := fmt.Sprintf("%s/solr/%s/schema", c.baseURL, collection)
+	if path != "" {
+		urlStr += "/" + path
+	}
+
+	if len(params) > 0 {
+		urlStr += "?" + params.Encode()
+	}
+
+	httpResp, err := c.reqSender.SendRequest(ctx, http.MethodGet, urlStr, JSON.String(), nil)
+	if err != nil {
+		return nil, wrapErr(err, "send request")
+	}
+
+	var resp schemaResponse
+	err = readResponse(httpResp, &resp)
+	if err != nil {
+		return nil, wrapErr(err, "read response")
+	}
+
+	return &resp, nil
 }
 
 // AddFields adds new field definitions to the schema.
 //
 // Refer to https://solr.apache.org/guide/8_8/schema-api.html#add-a-new-field
 func (c *JSONClient) AddFields(ctx context.Context, collection string, fields ...Field) error {
-	return c.modifySchema(ctx, collection, "add-field", fields)
+	return c.sendSchemaCommand(ctx, collection, "add-field", fields)
 }
 
 // DeleteFields removes field definitions from the schema.
 //
 // Refer to https://solr.apache.org/guide/8_8/schema-api.html#delete-a-field
 func (c *JSONClient) DeleteFields(ctx context.Context, collection string, fields ...Field) error {
-	return c.modifySchema(ctx, collection, "delete-field", fields)
+	return c.sendSchemaCommand(ctx, collection, "delete-field", fields)
 }
 
 // ReplaceFields replaces field definition from the schema.
 //
 // Refer to https://solr.apache.org/guide/8_8/schema-api.html#replace-a-field
 func (c *JSONClient) ReplaceFields(ctx context.Context, collection string, fields ...Field) error {
-	return c.modifySchema(ctx, collection, "replace-field", fields)
+	return c.sendSchemaCommand(ctx, collection, "replace-field", fields)
 }
 
 // AddDynamicFields adds new dynamic field rules to the schema.
 //
 // Refer to https://solr.apache.org/guide/8_8/schema-api.html#add-a-dynamic-field-rule
 func (c *JSONClient) AddDynamicFields(ctx context.Context, collection string, fields ...Field) error {
-	return c.modifySchema(ctx, collection, "add-dynamic-field", fields)
+	return c.sendSchemaCommand(ctx, collection, "add-dynamic-field", fields)
 }
 
 // DeleteDynamicFields removes dynamic field rules from the schema.
 //
 // Refer to https://solr.apache.org/guide/8_8/schema-api.html#delete-a-dynamic-field-rule
 func (c *JSONClient) DeleteDynamicFields(ctx context.Context, collection string, fields ...Field) error {
-	return c.modifySchema(ctx, collection, "delete-dynamic-field", fields)
+	return c.sendSchemaCommand(ctx, collection, "delete-dynamic-field", fields)
 }
 
 // ReplaceDynamicFields replaces dynamic field rules from the schema.
 //
 // Refer to https://solr.apache.org/guide/8_8/schema-api.html#replace-a-dynamic-field-rule
 func (c *JSONClient) ReplaceDynamicFields(ctx context.Context, collection string, fields ...Field) error {
-	return c.modifySchema(ctx, collection, "replace-dynamic-field", fields)
+	return c.sendSchemaCommand(ctx, collection, "replace-dynamic-field", fields)
 }
 
 // AddFieldTypes adds new field types to the schema.
 //
 // Refer to https://solr.apache.org/guide/8_8/schema-api.html#add-a-new-field-type
 func (c *JSONClient) AddFieldTypes(ctx context.Context, collection string, fieldTypes ...FieldType) error {
-	return c.modifySchema(ctx, collection, "add-field-type", fieldTypes)
+	return c.sendSchemaCommand(ctx, collection, "add-field-type", fieldTypes)
 }
 
 // DeleteFieldTypes removes field type definitions from the schema.
 //
 // Refer to https://solr.apache.org/guide/8_8/schema-api.html#delete-a-field-type
 func (c *JSONClient) DeleteFieldTypes(ctx context.Context, collection string, fieldTypes ...FieldType) error {
-	return c.modifySchema(ctx, collection, "delete-field-type", fieldTypes)
+	return c.sendSchemaCommand(ctx, collection, "delete-field-type", fieldTypes)
 }
 
 // ReplaceFieldTypes replaces field type defintions from the schema.
 //
 // Refer to https://solr.apache.org/guide/8_8/schema-api.html#replace-a-field-type
 func (c *JSONClient) ReplaceFieldTypes(ctx context.Context, collection string, fieldTypes ...FieldType) error {
-	return c.modifySchema(ctx, collection, "replace-field-type", fieldTypes)
+	return c.sendSchemaCommand(ctx, collection, "replace-field-type", fieldTypes)
 }
 
 // AddCopyFields adds new copy field rules to the schema.
 //
 // Refer to https://solr.apache.org/guide/8_8/schema-api.html#add-a-new-copy-field-rule
 func (c *JSONClient) AddCopyFields(ctx context.Context, collection string, copyFields ...CopyField) error {
-	return c.modifySchema(ctx, collection, "add-copy-field", copyFields)
+	return c.sendSchemaCommand(ctx, collection, "add-copy-field", copyFields)
 }
 
 // DeleteCopyFields deletes copy field rules from the schema.
 //
 // Refer to https://solr.apache.org/guide/8_8/schema-api.html#delete-a-copy-field-rule
 func (c *JSONClient) DeleteCopyFields(ctx context.Context, collection string, copyFields ...CopyField) error {
-	return c.modifySchema(ctx, collection, "delete-copy-field", copyFields)
+	return c.sendSchemaCommand(ctx, collection, "delete-copy-field", copyFields)
+}
+
+// Plan diffs the live schema of the collection with the desired schema and
+// returns the ordered changes to apply. The field types, fields, dynamic fields
+// and copy fields which are not in the desired schema are only deleted if
+// opts.Prune is true, opts can be nil. Changes that can lose data on a
+// populated index e.g. deleting a field are flagged as destructive.
+//
+// Refer to https://solr.apache.org/guide/8_8/schema-api.html#modify-the-schema
+func (c *JSONClient) Plan(ctx context.Context, collection string, desired Schema, opts *PlanOptions) (*SchemaPlan, error) {
+	if opts == nil {
+		opts = &PlanOptions{}
+	}
+
+	live, err := c.GetSchema(ctx, collection)
+	if err != nil {
+		return nil, wrapErr(err, "get schema")
+	}
+
+	queryResp, err := c.Query(ctx, collection, NewQuery("*:*").Limit(0))
+	if err != nil {
+		return nil, wrapErr(err, "query documents")
+	}
+	populated := queryResp.Response.NumFound > 0
+
+	return planSchema(*live, desired, populated, *opts)
+}
+
+// Apply sends the commands of the plan in a single schema request. It returns
+// ErrDestructiveChanges if the plan has destructive changes and allowDestructive is false.
+//
+// Refer to https://solr.apache.org/guide/8_8/schema-api.html#modify-the-schema
+func (c *JSONClient) Apply(ctx context.Context, collection string, plan *SchemaPlan, allowDestructive bool) error {
+	if plan == nil {
+		return errors.New("plan is required")
+	}
+
+	if !allowDestructive && len(plan.Destructive()) > 0 {
+		return ErrDestructiveChanges
+	}
+
+	return c.ModifySchema(ctx, collection, &SchemaBatch{commands: plan.Commands()})
 }
 
-func (c *JSONClient) modifySchema(ctx context.Context, collection, command string, body interface{}) error {
+// ModifySchema sends the commands of the batch in a single request which Solr
+// applies atomically. If a command fails, none of the commands are applied and
+// the returned *ResponseError has the error messages of each failed command.
+//
+// Refer to https://solr.apache.org/guide/8_8/schema-api.html#multiple-commands-in-a-single-post
+func (c *JSONClient) ModifySchema(ctx context.Context, collection string, batch *SchemaBatch) error {
+	if batch == nil {
+		return errors.New("batch is required")
+	}
+
+	if len(batch.commands) == 0 {
+		return nil
+	}
+
+	urlStr := fmt.Sprintf("%s/solr/%s/schema", c.baseURL, collection)
+	return c.postJSON(ctx, urlStr, batch)
+}
+
+func (c *JSONClient) sendSchemaCommand(ctx context.Context, collection, command string, body interface{}) error {
 	urlStr := fmt.Sprintf("%s/solr/%s/schema", c.baseURL, collection)
 	return c.postJSON(ctx, urlStr, M{command: body})
 }
 
 func (c *JSONClient) postJSON(ctx context.Context, urlStr string, reqBody interface{}) error {
+	return c.sendJSON(ctx, http.MethodPost, urlStr, reqBody)
+}
+
+func (c *JSONClient) sendJSON(ctx context.Context, method, urlStr string, reqBody interface{}) error {
 	buf := &bytes.Buffer{}
 	err := json.NewEncoder(buf).Encode(reqBody)
 	if err != nil {
 		return wrapErr(err, "encode request body")
 	}
 
-	httpResp, err := c.reqSender.SendRequest(ctx, http.MethodPost, urlStr, JSON.String(), buf)
+	httpResp, err := c.reqSender.SendRequest(ctx, method, urlStr, JSON.String(), buf)
+	if err != nil {
+		return wrapErr(err, "send request")
+	}
+
+	var resp BaseResponse
+	err = readResponse(httpResp, &resp)
+	if err != nil {
+		return wrapErr(err, "read response")
+	}
+
+	return nil
+}
+
+// UploadFeatures uploads the features to the feature store.
+//
+// Refer to https://solr.apache.org/guide/8_8/learning-to-rank.html#uploading-features
+func (c *JSONClient) UploadFeatures(ctx context.Context, collection string, features ...LTRFeature) error {
+	urlStr := fmt.Sprintf("%s/solr/%s/schema/feature-store", c.baseURL, collection)
+	return c.sendJSON(ctx, http.MethodPut, urlStr, features)
+}
+
+// ListFeatureStores returns the names of the feature stores.
+//
+// Refer to https://solr.apache.org/guide/8_8/learning-to-rank.html#uploading-features
+func (c *JSONClient) ListFeatureStores(ctx context.Context, collection string) ([]string, error) {
+	urlStr := fmt.Sprintf("%s/solr/%s/schema/feature-store", c.baseURL, collection)
+	httpResp, err := c.reqSender.SendRequest(ctx, http.MethodGet, urlStr, JSON.String(), nil)
+	if err != nil {
+		return nil, wrapErr(err, "send request")
+	}
+
+	var resp listFeatureStoresResponse
+	err = readResponse(httpResp, &resp)
+	if err != nil {
+		return nil, wrapErr(err, "read response")
+	}
+
+	return resp.FeatureStores, nil
+}
+
+// ListFeatures returns the features of the feature store.
+//
+// Refer to https://solr.apache.org/guide/8_8/learning-to-rank.html#uploading-features
+func (c *JSONClient) ListFeatures(ctx context.Context, collection, store string) ([]LTRFeature, error) {
+	urlStr := fmt.Sprintf("%s/solr/%s/schema/feature-store/%s", c.baseURL, collection, url.PathEscape(store))
+	httpResp, err := c.reqSender.SendRequest(ctx, http.MethodGet, urlStr, JSON.String(), nil)
+	if err != nil {
+		return nil, wrapErr(err, "send request")
+	}
+
+	var resp listFeaturesResponse
+	err = readResponse(httpResp, &resp)
+	if err != nil {
+		return nil, wrapErr(err, "read response")
+	}
+
+	return resp.Features, nil
+}
+
+// DeleteFeatureStore deletes the feature store and all of its features.
+//
+// Refer to https://solr.apache.org/guide/8_8/learning-to-rank.html#uploading-features
+func (c *JSONClient) DeleteFeatureStore(ctx context.Context, collection, store string) error {
+	urlStr := fmt.Sprintf("%s/solr/%s/schema/feature-store/%s", c.baseURL, collection, url.PathEscape(store))
+	return c.deleteResource(ctx, urlStr)
+}
+
+// UploadModels uploads the models to the model store.
+//
+// Refer to https://solr.apache.org/guide/8_8/learning-to-rank.html#uploading-a-model
+func (c *JSONClient) UploadModels(ctx context.Context, collection string, models ...LTRModel) error {
+	urlStr := fmt.Sprintf("%s/solr/%s/schema/model-store", c.baseURL, collection)
+	return c.sendJSON(ctx, http.MethodPut, urlStr, models)
+}
+
+// ListModels returns the models of the model store.
+//
+// Refer to https://solr.apache.org/guide/8_8/learning-to-rank.html#uploading-a-model
+func (c *JSONClient) ListModels(ctx context.Context, collection string) ([]LTRModel, error) {
+	urlStr := fmt.Sprintf("%s/solr/%s/schema/model-store", c.baseURL, collection)
+	httpResp, err := c.reqSender.SendRequest(ctx, http.MethodGet, urlStr, JSON.String(), nil)
+	if err != nil {
+		return nil, wrapErr(err, "send request")
+	}
+
+	var resp listModelsResponse
+	err = readResponse(httpResp, &resp)
+	if err != nil {
+		return nil, wrapErr(err, "read response")
+	}
+
+	return resp.Models, nil
+}
+
+// DeleteModel deletes the model from the model store.
+//
+// Refer to https://solr.apache.org/guide/8_8/learning-to-rank.html#uploading-a-model
+func (c *JSONClient) DeleteModel(ctx context.Context, collection, model string) error {
+	urlStr := fmt.Sprintf("%s/solr/%s/schema/model-store/%s", c.baseURL, collection, url.PathEscape(model))
+	return c.deleteResource(ctx, urlStr)
+}
+
+// deleteResource deletes a managed resource
+func (c *JSONClient) deleteResource(ctx context.Context, urlStr string) error {
+	httpResp, err := c.reqSender.SendRequest(ctx, http.MethodDelete, urlStr, JSON.String(), nil)
 	if err != nil {
 		return wrapErr(err, "send request")
 	}
@@ -362,6 +879,130 @@ func (c *JSONClient) DeleteComponents(ctx context.Context, collection string, co
 	return errors.New("not implemented")
 }
 
+// MoreLikeThis finds documents similar to a document or
+// to a text (stream body) via the more like this handler.
+//
+// Refer to https://solr.apache.org/guide/8_8/morelikethis.html#using-the-morelikethishandler
+func (c *JSONClient) MoreLikeThis(ctx context.Context, collection string, params *MoreLikeThisParams) (*MoreLikeThisResponse, error) {
+	urlStr := fmt.Sprintf("%s/solr/%s/%s?%s", c.baseURL, collection, params.endpoint, params.BuildParams())
+
+	var (
+		httpResp *http.Response
+		err      error
+	)
+	if params.stream != "" {
+		httpResp, err = c.reqSender.SendRequest(ctx, http.MethodPost, urlStr,
+			Text.String(), strings.NewReader(params.stream))
+	} else {
+		httpResp, err = c.reqSender.SendRequest(ctx, http.MethodGet, urlStr, JSON.String(), nil)
+	}
+	if err != nil {
+		return nil, wrapErr(err, "send request")
+	}
+
+	var resp MoreLikeThisResponse
+	err = readResponse(httpResp, &resp)
+	if err != nil {
+		return nil, wrapErr(err, "read response")
+	}
+
+	return &resp, nil
+}
+
+// StreamTuples sends the streaming expression to the stream handler and
+// returns the iterator over the result tuples, which must be closed if it
+// is not iterated until the end.
+//
+// Refer to https://solr.apache.org/guide/8_8/streaming-expressions.html#streaming-requests-and-responses
+func (c *JSONClient) StreamTuples(ctx context.Context, collection string, expr *StreamExpr) (*TupleStream, error) {
+	urlStr := fmt.Sprintf("%s/solr/%s/stream", c.baseURL, collection)
+	body := url.Values{"expr": {expr.String()}}.Encode()
+	httpResp, err := c.reqSender.SendRequest(ctx, http.MethodPost, urlStr, Form.String(), strings.NewReader(body))
+	if err != nil {
+		return nil, wrapErr(err, "send request")
+	}
+
+	stream, err := readTupleStream(httpResp, true, "result-set", "docs")
+	if err != nil {
+		return nil, wrapErr(err, "read response")
+	}
+
+	return stream, nil
+}
+
+// SQL sends the SQL statement to the parallel SQL interface and returns the
+// iterator over the result tuples, which must be closed if it is not iterated
+// until the end. The fields of the tuples in order are available via Fields.
+// opts is optional.
+//
+// Refer to https://solr.apache.org/guide/8_8/parallel-sql-interface.html
+func (c *JSONClient) SQL(ctx context.Context, collection, stmt string, opts *SQLOptions) (*TupleStream, error) {
+	urlStr := fmt.Sprintf("%s/solr/%s/sql", c.baseURL, collection)
+	body := url.Values{"stmt": {stmt}, "includeMetadata": {"true"}}.Encode()
+	if opts != nil {
+		if params := opts.BuildParams(); params != "" {
+			body += "&" + params
+		}
+	}
+
+	httpResp, err := c.reqSender.SendRequest(ctx, http.MethodPost, urlStr, Form.String(), strings.NewReader(body))
+	if err != nil {
+		return nil, wrapErr(err, "send request")
+	}
+
+	stream, err := readTupleStream(httpResp, true, "result-set", "docs")
+	if err != nil {
+		return nil, wrapErr(err, "read response")
+	}
+
+	return stream, nil
+}
+
+// Export streams all the documents matching the query from the export
+// handler. It returns the iterator over the documents, which decodes one
+// document at a time and must be closed if it is not iterated until the end.
+// The fields (fl) and the sort fields must have docValues, which is checked
+// via the schema API before the documents are requested.
+//
+// Refer to https://solr.apache.org/guide/8_8/exporting-result-sets.html
+func (c *JSONClient) Export(ctx context.Context, collection, query, sort string, fl ...string) (*TupleStream, error) {
+	if len(fl) == 0 || sort == "" {
+		return nil, errors.New("export requires the fields (fl) and the sort")
+	}
+
+	names := append(append([]string{}, fl...), sortFields(sort)...)
+	// include the dynamic fields that match the names and
+	// the docValues property inherited from the field types
+	params := url.Values{
+		"fl":             {strings.Join(names, ",")},
+		"includeDynamic": {"true"},
+		"showDefaults":   {"true"},
+	}
+	schemaResp, err := c.readSchema(ctx, collection, "fields", params)
+	if err != nil {
+		return nil, wrapErr(err, "list fields")
+	}
+
+	err = checkDocValues(schemaResp.Fields, names)
+	if err != nil {
+		return nil, err
+	}
+
+	params = url.Values{"q": {query}, "sort": {sort}, "fl": {strings.Join(fl, ",")}}
+	urlStr := fmt.Sprintf("%s/solr/%s/export?%s", c.baseURL, collection, params.Encode())
+	httpResp, err := c.reqSender.SendRequest(ctx, http.MethodGet, urlStr, JSON.String(), nil)
+	if err != nil {
+		return nil, wrapErr(err, "send request")
+	}
+
+	stream, err := readTupleStream(httpResp, false, "response", "docs")
+	if err != nil {
+		return nil, wrapErr(err, "read response")
+	}
+
+	return stream, nil
+}
+
 // Suggest queries the suggest endpoint.
 //
 // Refer to https://solr.apache.org/guide/8_8/suggester.html#get-suggestions-with-weights
@@ -381,6 +1022,31 @@ func (c *JSONClient) Suggest(ctx context.Context, collection string, params *Sug
 	return &resp, nil
 }
 
+// readTupleStream returns the iterator over the tuples of the list
+// located at the path of the response body, or the response error.
+// eofTuple is true if the list ends with the EOF tuple.
+func readTupleStream(resp *http.Response, eofTuple bool, path ...string) (*TupleStream, error) {
+	if resp.StatusCode > http.StatusOK ||
+		strings.Contains(resp.Header.Get("content-type"), "text/html") {
+		defer resp.Body.Close()
+
+		var errResp BaseResponse
+		err := readResponse(resp, &errResp)
+		if err != nil {
+			return nil, err
+		}
+
+		return nil, fmt.Errorf("unexpected response status %d", resp.StatusCode)
+	}
+
+	return newTupleStream(resp.Body, eofTuple, path...)
+}
+
+// readResponse decodes the json response into v. A status above 200 is
+// returned as an error for every endpoint: the *ResponseError of the
+// response if v embeds *BaseResponse, otherwise a *ResponseError with the
+// status text, and a *VersionConflictError for 409. Previously, only the
+// endpoints decoding into a bare *BaseResponse returned an error.
 func readResponse(resp *http.Response, v interface{}) error {
 	contentType := resp.Header.Get("content-type")
 	if strings.Contains(contentType, "text/html") {
@@ -397,9 +1063,24 @@ func readResponse(resp *http.Response, v interface{}) error {
 		return wrapErr(err, "decode json response")
 	}
 
-	val, ok := v.(*BaseResponse)
-	if ok && resp.StatusCode > http.StatusOK {
-		return val.Error
+	if resp.StatusCode > http.StatusOK {
+		var respErr *ResponseError
+		if val, ok := v.(interface{ responseError() *ResponseError }); ok {
+			respErr = val.responseError()
+		}
+
+		if respErr == nil {
+			respErr = &ResponseError{
+				Code: resp.StatusCode,
+				Msg:  http.StatusText(resp.StatusCode),
+			}
+		}
+
+		if resp.StatusCode == http.StatusConflict {
+			return &VersionConflictError{ResponseError: respErr}
+		}
+
+		return respErr
 	}
 
 	return nil
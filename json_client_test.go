@@ -9,6 +9,7 @@ import (
 	"io"
 	"net/http"
 	"reflect"
+	"strings"
 	"testing"
 
 	"github.com/jarcoal/httpmock"
@@ -174,6 +175,120 @@ func TestJSONClientMock(t *testing.T) {
 
 		_, err = clientThatErrors.Query(ctx, collection, query)
 		assert.ErrorIs(t, err, errSendRequest)
+
+		httpmock.RegisterResponder(
+			http.MethodPost,
+			baseURL+"/solr/"+collection+"/query",
+			httpmock.NewStringResponder(http.StatusBadRequest,
+				`{"responseHeader":{"status":400,"QTime":1},"error":{"code":400,"msg":"undefined field bad"}}`),
+		)
+
+		_, err = client.Query(ctx, collection, NewQuery("bad:query"))
+		var respErr *ResponseError
+		require.ErrorAs(t, err, &respErr)
+		assert.Equal(t, 400, respErr.Code)
+		assert.Equal(t, "undefined field bad", respErr.Msg)
+	})
+
+	t.Run("query with collation", func(t *testing.T) {
+		httpmock.RegisterResponder(
+			http.MethodPost,
+			baseURL+"/solr/"+collection+"/query",
+			func(r *http.Request) (*http.Response, error) {
+				var body M
+				err := json.NewDecoder(r.Body).Decode(&body)
+				if err != nil {
+					return nil, err
+				}
+
+				if body["query"] == "dell" {
+					return httpmock.NewStringResponse(http.StatusOK,
+						`{"response":{"numFound":1,"docs":[{"id":"1"}]}}`), nil
+				}
+
+				return httpmock.NewStringResponse(http.StatusOK,
+					`{"response":{"numFound":0,"docs":[]},"spellcheck":{"suggestions":[],"collations":["collation","dell"]}}`), nil
+			},
+		)
+
+		query := NewQuery("delll").Spellcheck(NewSpellcheck().Collate(true))
+		resp, collation, err := client.QueryWithCollation(ctx, collection, query)
+		require.NoError(t, err)
+		assert.Equal(t, "dell", collation)
+		assert.Equal(t, 1, resp.Response.NumFound)
+
+		resp, collation, err = client.QueryWithCollation(ctx, collection, NewQuery("dell"))
+		require.NoError(t, err)
+		assert.Equal(t, "", collation)
+		assert.Equal(t, 1, resp.Response.NumFound)
+
+		_, _, err = clientThatErrors.QueryWithCollation(ctx, collection, query)
+		assert.ErrorIs(t, err, errSendRequest)
+	})
+
+	t.Run("query stream", func(t *testing.T) {
+		httpmock.RegisterResponder(
+			http.MethodPost,
+			baseURL+"/solr/"+collection+"/query",
+			func(r *http.Request) (*http.Response, error) {
+				var body M
+				err := json.NewDecoder(r.Body).Decode(&body)
+				if err != nil {
+					return nil, err
+				}
+
+				switch body["query"] {
+				case "bad:query":
+					return httpmock.NewStringResponse(http.StatusBadRequest,
+						`{"error":{"code":400,"msg":"undefined field bad"}}`), nil
+				case "truncated":
+					return httpmock.NewStringResponse(http.StatusOK,
+						`{"response":{"numFound":2,"docs":[{"id":"1"},{"id"`), nil
+				}
+
+				return httpmock.NewStringResponse(http.StatusOK, `{
+					"responseHeader":{"status":0,"QTime":2},
+					"response":{"numFound":3,"start":0,"maxScore":1.5,"docs":[{"id":"1"},{"id":"2"},{"id":"3"}]},
+					"facets":{"count":3,"categories":{"buckets":[{"val":"a","count":2}]}}}`), nil
+			},
+		)
+
+		ids := []interface{}{}
+		resp, err := client.QueryStream(ctx, collection, NewQuery("*:*").Limit(10000), func(doc M) error {
+			ids = append(ids, doc["id"])
+			return nil
+		})
+		require.NoError(t, err)
+		assert.Equal(t, []interface{}{"1", "2", "3"}, ids)
+		assert.Equal(t, 0, resp.Header.Status)
+		assert.Equal(t, 2, resp.Header.QTime)
+		assert.Equal(t, 3, resp.Response.NumFound)
+		assert.Equal(t, 1.5, resp.Response.MaxScore)
+		assert.Nil(t, resp.Response.Documents)
+		assert.Equal(t, 2, resp.FacetResults().Facet("categories").Buckets[0].Count)
+
+		errStop := errors.New("stop")
+		count := 0
+		_, err = client.QueryStream(ctx, collection, NewQuery("*:*"), func(doc M) error {
+			count++
+			return errStop
+		})
+		assert.ErrorIs(t, err, errStop)
+		assert.Equal(t, 1, count)
+
+		_, err = client.QueryStream(ctx, collection, NewQuery("bad:query"), func(doc M) error { return nil })
+		var respErr *ResponseError
+		require.ErrorAs(t, err, &respErr)
+		assert.Equal(t, "undefined field bad", respErr.Msg)
+
+		_, err = client.QueryStream(ctx, collection, NewQuery("truncated"), func(doc M) error { return nil })
+		assert.Error(t, err)
+
+		_, err = clientThatErrors.QueryStream(ctx, collection, NewQuery("*:*"), func(doc M) error { return nil })
+		assert.ErrorIs(t, err, errSendRequest)
+
+		_, err = client.QueryStream(ctx, collection, NewQuery("*:*"), nil)
+		assert.EqualError(t, err, "fn is required")
 	})
 
 	t.Run("update and commit", func(t *testing.T) {
@@ -218,17 +333,463 @@ func TestJSONClientMock(t *testing.T) {
 		_, err = client.Update(ctx, collection, JSON, buf)
 		assert.NoError(t, err)
 
-		err = client.Commit(ctx, collection)
+		_, err = client.Commit(ctx, collection, nil)
 		assert.NoError(t, err)
 
 		_, err = clientThatErrors.Update(ctx, collection, JSON, buf)
 		assert.ErrorIs(t, err, errSendRequest)
 
-		err = clientThatErrors.Commit(ctx, collection)
+		_, err = clientThatErrors.Commit(ctx, collection, nil)
+		assert.ErrorIs(t, err, errSendRequest)
+	})
+
+	t.Run("soft commit and optimize", func(t *testing.T) {
+		httpmock.RegisterResponder(
+			http.MethodGet,
+			baseURL+"/solr/"+collection+"/update",
+			func(r *http.Request) (*http.Response, error) {
+				gotQuery := r.URL.Query().Encode()
+				if gotQuery != "commit=true&softCommit=true&waitSearcher=false" &&
+					gotQuery != "maxSegments=2&optimize=true" {
+					return nil, fmt.Errorf("unexpected url query %q", gotQuery)
+				}
+
+				return httpmock.NewJsonResponse(http.StatusOK, M{
+					"responseHeader": M{"status": 0, "QTime": 3},
+				})
+			},
+		)
+
+		resp, err := client.Commit(ctx, collection, NewCommitOptions().
+			SoftCommit(true).WaitSearcher(false))
+		require.NoError(t, err)
+		assert.Equal(t, 3, resp.Header.QTime)
+
+		_, err = client.Commit(ctx, collection, NewCommitOptions().Optimize(2))
+		assert.NoError(t, err)
+	})
+
+	t.Run("update with params", func(t *testing.T) {
+		httpmock.RegisterResponder(
+			http.MethodPost,
+			baseURL+"/solr/"+collection+"/update",
+			func(r *http.Request) (*http.Response, error) {
+				query := "versions=true"
+				gotQuery := r.URL.Query().Encode()
+				if gotQuery != query {
+					return nil, fmt.Errorf("expecting url query to be %q but got %q", query, gotQuery)
+				}
+
+				return httpmock.NewStringResponse(http.StatusOK,
+					`{"responseHeader":{"status":0},"adds":["1",1632740120218042368]}`), nil
+			},
+		)
+
+		b, err := json.Marshal(NewUpdateCommands().
+			AddWithVersion(M{"id": "1"}, VersionMustNotExist))
+		require.NoError(t, err)
+
+		params := NewUpdateParams().Versions(true)
+		resp, err := client.UpdateWithParams(ctx, collection, JSON, bytes.NewReader(b), params)
+		require.NoError(t, err)
+		assert.Equal(t, DocVersions{{ID: "1", Version: 1632740120218042368}}, resp.Adds)
+
+		_, err = clientThatErrors.UpdateWithParams(ctx, collection, JSON, bytes.NewReader(b), params)
+		assert.ErrorIs(t, err, errSendRequest)
+	})
+
+	t.Run("real-time get", func(t *testing.T) {
+		httpmock.RegisterResponder(
+			http.MethodGet,
+			baseURL+"/solr/"+collection+"/get",
+			func(r *http.Request) (*http.Response, error) {
+				query := "fl=id%2Cname&id=1&id=2"
+				gotQuery := r.URL.Query().Encode()
+				if gotQuery != query {
+					return nil, fmt.Errorf("expecting url query to be %q but got %q", query, gotQuery)
+				}
+
+				return httpmock.NewStringResponse(http.StatusOK,
+					`{"response":{"numFound":2,"start":0,"docs":[{"id":"1","name":"product 1"},{"id":"2","name":"product 2"}]}}`), nil
+			},
+		)
+
+		params := NewRealTimeGetParams("1", "2").Fields("id", "name")
+		resp, err := client.GetWithParams(ctx, collection, params)
+		require.NoError(t, err)
+		assert.Len(t, resp.Documents, 2)
+
+		_, err = clientThatErrors.Get(ctx, collection, "1", "2")
+		assert.ErrorIs(t, err, errSendRequest)
+	})
+
+	t.Run("update with retry", func(t *testing.T) {
+		versions := []string{"1632740120218042368", "1632740120218042369"}
+		gets := 0
+		httpmock.RegisterResponder(
+			http.MethodGet,
+			baseURL+"/solr/"+collection+"/get",
+			func(r *http.Request) (*http.Response, error) {
+				if id := r.URL.Query().Get("id"); id != "1" {
+					return nil, fmt.Errorf("unexpected id %q", id)
+				}
+
+				version := versions[gets%len(versions)]
+				gets++
+				return httpmock.NewStringResponse(http.StatusOK,
+					`{"doc":{"id":"1","count":1,"_version_":`+version+`}}`), nil
+			},
+		)
+
+		updates := 0
+		httpmock.RegisterResponder(
+			http.MethodPost,
+			baseURL+"/solr/"+collection+"/update",
+			func(r *http.Request) (*http.Response, error) {
+				var body map[string]struct {
+					Doc map[string]json.Number `json:"doc"`
+				}
+				dec := json.NewDecoder(r.Body)
+				dec.UseNumber()
+				err := dec.Decode(&body)
+				if err != nil {
+					return nil, err
+				}
+
+				expect := map[string]json.Number{"id": "1", "count": "2", "_version_": json.Number(versions[updates])}
+				if !reflect.DeepEqual(expect, body["add"].Doc) {
+					return nil, fmt.Errorf("unexpected doc %v", body["add"].Doc)
+				}
+
+				updates++
+				if updates == 1 {
+					return httpmock.NewStringResponse(http.StatusConflict,
+						`{"error":{"code":409,"msg":"version conflict"}}`), nil
+				}
+
+				return httpmock.NewStringResponse(http.StatusOK, `{"adds":["1",1632740120218042370]}`), nil
+			},
+		)
+
+		resp, err := client.UpdateWithRetry(ctx, collection, "1", func(doc M) (M, error) {
+			count, err := doc["count"].(json.Number).Int64()
+			if err != nil {
+				return nil, err
+			}
+
+			doc["count"] = count + 1
+			return doc, nil
+		})
+		require.NoError(t, err)
+		assert.Equal(t, 2, updates)
+		assert.Equal(t, DocVersions{{ID: "1", Version: 1632740120218042370}}, resp.Adds)
+
+		_, err = client.UpdateWithRetry(ctx, collection, "1", func(doc M) (M, error) {
+			return nil, assert.AnError
+		})
+		assert.ErrorIs(t, err, assert.AnError)
+
+		_, err = client.UpdateWithRetry(ctx, collection, "1", func(doc M) (M, error) {
+			return nil, nil
+		})
+		assert.EqualError(t, err, "apply update: fn returned a nil document")
+
+		_, err = clientThatErrors.UpdateWithRetry(ctx, collection, "1", func(doc M) (M, error) {
+			return doc, nil
+		})
 		assert.ErrorIs(t, err, errSendRequest)
+
+		conflicts := 0
+		httpmock.RegisterResponder(
+			http.MethodPost,
+			baseURL+"/solr/"+collection+"/update",
+			func(r *http.Request) (*http.Response, error) {
+				conflicts++
+				return httpmock.NewStringResponse(http.StatusConflict,
+					`{"error":{"code":409,"msg":"version conflict"}}`), nil
+			},
+		)
+
+		_, err = client.UpdateWithRetry(ctx, collection, "1", func(doc M) (M, error) {
+			return doc, nil
+		})
+		assert.True(t, IsVersionConflict(err))
+		assert.Equal(t, maxUpdateAttempts, conflicts)
+
+		cancelledCtx, cancel := context.WithCancel(ctx)
+		cancel()
+		_, err = client.UpdateWithRetry(cancelledCtx, collection, "1", func(doc M) (M, error) {
+			return doc, nil
+		})
+		assert.ErrorIs(t, err, context.Canceled)
+	})
+
+	t.Run("version conflict", func(t *testing.T) {
+		httpmock.RegisterResponder(
+			http.MethodPost,
+			baseURL+"/solr/"+collection+"/update",
+			httpmock.NewStringResponder(http.StatusConflict,
+				`{"error":{"code":409,"msg":"version conflict for 1 expected=1 actual=-1"}}`),
+		)
+
+		b, err := json.Marshal(NewUpdateCommands().DeleteWithVersion("1", VersionMustExist))
+		require.NoError(t, err)
+
+		_, err = client.Update(ctx, collection, JSON, bytes.NewReader(b))
+		assert.True(t, IsVersionConflict(err))
 	})
 
 	t.Run("schema", func(t *testing.T) {
+		t.Run("read schema", func(t *testing.T) {
+			schemaURL := baseURL + "/solr/" + collection + "/schema"
+			responses := map[string]string{
+				"": `{"schema":{"name":"example","version":1.6,"uniqueKey":"id",
+					"similarity":{"class":"org.apache.solr.search.similarities.SchemaSimilarityFactory"},
+					"fieldTypes":[{"name":"string","class":"solr.StrField","sortMissingLast":true,"docValues":true}],
+					"fields":[{"name":"id","type":"string","indexed":true,"stored":true,"required":true}],
+					"dynamicFields":[{"name":"*_s","type":"string","indexed":true,"stored":true}],
+					"copyFields":[{"source":"name","dest":"_text_"}]}}`,
+				"/fields?includeDynamic=true&showDefaults=false": `{"fields":[{"name":"id","type":"string"},{"name":"name_s","type":"string","dynamicBase":"*_s"}]}`,
+				"/fields/id?showDefaults=true":                   `{"field":{"name":"id","type":"string","indexed":true,"stored":true,"docValues":true,"multiValued":false}}`,
+				"/fields/missing?showDefaults=false":             `{"error":{"code":404,"msg":"No such path /schema/fields/missing"}}`,
+				"/dynamicfields?showDefaults=false":              `{"dynamicFields":[{"name":"*_s","type":"string"}]}`,
+				"/fieldtypes?showDefaults=true": `{"fieldTypes":[{"name":"text_general","class":"solr.TextField",
+					"positionIncrementGap":"100","autoGeneratePhraseQueries":false,"indexed":true,
+					"indexAnalyzer":{"tokenizer":{"class":"solr.StandardTokenizerFactory"},
+						"filters":[{"class":"solr.EdgeNGramFilterFactory","minGramSize":"1","maxGramSize":"20"}]}}]}`,
+				"/copyfields": `{"copyFields":[{"source":"name","dest":"_text_","maxChars":"256"}]}`,
+				"/uniquekey":  `{"uniqueKey":"id"}`,
+				"/version":    `{"version":1.6}`,
+				"/similarity": `{"similarity":{"class":"solr.BM25SimilarityFactory","k1":"1.2","b":"0.75"}}`,
+			}
+
+			for path, body := range responses {
+				path, body := path, body
+				status := http.StatusOK
+				if strings.Contains(path, "missing") {
+					status = http.StatusNotFound
+				}
+
+				urlStr := schemaURL + path
+				if i := strings.Index(path, "?"); i >= 0 {
+					urlStr = schemaURL + path[:i]
+				}
+
+				query := ""
+				if i := strings.Index(path, "?"); i >= 0 {
+					query = path[i+1:]
+				}
+
+				httpmock.RegisterResponder(http.MethodGet, urlStr, func(r *http.Request) (*http.Response, error) {
+					if r.URL.RawQuery != query {
+						return nil, fmt.Errorf("expecting url query to be %q but got %q", query, r.URL.RawQuery)
+					}
+
+					return httpmock.NewStringResponse(status, body), nil
+				})
+			}
+
+			schema, err := client.GetSchema(ctx, collection)
+			require.NoError(t, err)
+			assert.Equal(t, "example", schema.Name)
+			assert.Equal(t, 1.6, schema.Version)
+			assert.Equal(t, "id", schema.UniqueKey)
+			assert.Equal(t, "org.apache.solr.search.similarities.SchemaSimilarityFactory", schema.Similarity.Class)
+			assert.Equal(t, []FieldType{{Name: "string", Class: "solr.StrField", SortMissingLast: Bool(true), DocValues: Bool(true)}}, schema.FieldTypes)
+			assert.Equal(t, []Field{{Name: "id", Type: "string", Indexed: Bool(true), Stored: Bool(true), Required: Bool(true)}}, schema.Fields)
+			assert.Equal(t, []Field{{Name: "*_s", Type: "string", Indexed: Bool(true), Stored: Bool(true)}}, schema.DynamicFields)
+			assert.Equal(t, []CopyField{{Source: "name", Dest: "_text_"}}, schema.CopyFields)
+
+			fields, err := client.ListFields(ctx, collection, true, false)
+			require.NoError(t, err)
+			assert.Equal(t, []Field{{Name: "id", Type: "string"}, {Name: "name_s", Type: "string", Properties: M{"dynamicBase": "*_s"}}}, fields)
+
+			field, err := client.GetField(ctx, collection, "id", true)
+			require.NoError(t, err)
+			assert.Equal(t, &Field{Name: "id", Type: "string", Indexed: Bool(true), Stored: Bool(true), DocValues: Bool(true), MultiValued: Bool(false)}, field)
+
+			_, err = client.GetField(ctx, collection, "missing", false)
+			var respErr *ResponseError
+			require.ErrorAs(t, err, &respErr)
+			assert.Equal(t, http.StatusNotFound, respErr.Code)
+
+			dynamicFields, err := client.ListDynamicFields(ctx, collection, false)
+			require.NoError(t, err)
+			assert.Equal(t, []Field{{Name: "*_s", Type: "string"}}, dynamicFields)
+
+			fieldTypes, err := client.ListFieldTypes(ctx, collection, true)
+			require.NoError(t, err)
+			require.Len(t, fieldTypes, 1)
+			assert.Equal(t, "100", fieldTypes[0].PositionIncrementGap)
+			assert.Equal(t, "false", fieldTypes[0].AutoGeneratePhraseQueries)
+			assert.Equal(t, 1, fieldTypes[0].IndexAnalyzer.Filters[0].MinGramSize)
+			assert.Equal(t, 20, fieldTypes[0].IndexAnalyzer.Filters[0].MaxGramSize)
+
+			copyFields, err := client.ListCopyFields(ctx, collection)
+			require.NoError(t, err)
+			assert.Equal(t, []CopyField{{Source: "name", Dest: "_text_", MaxChars: 256}}, copyFields)
+
+			uniqueKey, err := client.GetUniqueKey(ctx, collection)
+			require.NoError(t, err)
+			assert.Equal(t, "id", uniqueKey)
+
+			version, err := client.GetSchemaVersion(ctx, collection)
+			require.NoError(t, err)
+			assert.Equal(t, 1.6, version)
+
+			similarity, err := client.GetSimilarity(ctx, collection)
+			require.NoError(t, err)
+			assert.Equal(t, &Similarity{Class: "solr.BM25SimilarityFactory", Params: M{"k1": "1.2", "b": "0.75"}}, similarity)
+
+			_, err = clientThatErrors.GetSchema(ctx, collection)
+			assert.ErrorIs(t, err, errSendRequest)
+
+			_, err = clientThatErrors.ListFields(ctx, collection, false, false)
+			assert.ErrorIs(t, err, errSendRequest)
+
+			_, err = clientThatErrors.GetField(ctx, collection, "id", false)
+			assert.ErrorIs(t, err, errSendRequest)
+
+			_, err = clientThatErrors.ListDynamicFields(ctx, collection, false)
+			assert.ErrorIs(t, err, errSendRequest)
+
+			_, err = clientThatErrors.ListFieldTypes(ctx, collection, false)
+			assert.ErrorIs(t, err, errSendRequest)
+
+			_, err = clientThatErrors.ListCopyFields(ctx, collection)
+			assert.ErrorIs(t, err, errSendRequest)
+
+			_, err = clientThatErrors.GetUniqueKey(ctx, collection)
+			assert.ErrorIs(t, err, errSendRequest)
+
+			_, err = clientThatErrors.GetSchemaVersion(ctx, collection)
+			assert.ErrorIs(t, err, errSendRequest)
+
+			_, err = clientThatErrors.GetSimilarity(ctx, collection)
+			assert.ErrorIs(t, err, errSendRequest)
+		})
+
+		t.Run("plan and apply", func(t *testing.T) {
+			// the live schema is registered by the read schema test
+			httpmock.RegisterResponder(
+				http.MethodPost,
+				baseURL+"/solr/"+collection+"/query",
+				newResponder(`{"query":"*:*","limit":0}`, M{"response": M{"numFound": 3, "docs": []M{}}}),
+			)
+
+			desired := Schema{
+				FieldTypes: []FieldType{
+					{Name: "string", Class: "solr.StrField", SortMissingLast: Bool(true), DocValues: Bool(true)},
+					{Name: "plong", Class: "solr.LongPointField"},
+				},
+				Fields: []Field{
+					{Name: "id", Type: "string", Indexed: Bool(true), Stored: Bool(true), Required: Bool(true)},
+					{Name: "stock", Type: "plong", Stored: Bool(true)},
+				},
+			}
+
+			plan, err := client.Plan(ctx, collection, desired, nil)
+			require.NoError(t, err)
+			assert.Equal(t, `add-field-type {"name":"plong","class":"solr.LongPointField"}
+add-field {"name":"stock","type":"plong","stored":true}`, plan.String())
+
+			plan, err = client.Plan(ctx, collection, desired, &PlanOptions{Prune: true})
+			require.NoError(t, err)
+			assert.Equal(t, `add-field-type {"name":"plong","class":"solr.LongPointField"}
+delete-copy-field {"source":"name","dest":"_text_"}
+add-field {"name":"stock","type":"plong","stored":true}
+delete-dynamic-field {"name":"*_s"} (destructive: the values of the existing documents are lost)`, plan.String())
+
+			err = client.Apply(ctx, collection, plan, false)
+			assert.ErrorIs(t, err, ErrDestructiveChanges)
+
+			expectBody := `{"add-field-type":{"name":"plong","class":"solr.LongPointField"},` +
+				`"delete-copy-field":{"source":"name","dest":"_text_"},` +
+				`"add-field":{"name":"stock","type":"plong","stored":true},` +
+				`"delete-dynamic-field":{"name":"*_s"}}`
+			httpmock.RegisterResponder(
+				http.MethodPost,
+				baseURL+"/solr/"+collection+"/schema",
+				func(r *http.Request) (*http.Response, error) {
+					b, err := io.ReadAll(r.Body)
+					if err != nil {
+						return nil, err
+					}
+
+					if strings.TrimSpace(string(b)) != expectBody {
+						return nil, fmt.Errorf("unexpected request body: %s", b)
+					}
+
+					return httpmock.NewStringResponse(http.StatusOK, `{}`), nil
+				},
+			)
+
+			err = client.Apply(ctx, collection, plan, true)
+			require.NoError(t, err)
+
+			err = client.Apply(ctx, collection, &SchemaPlan{}, false)
+			require.NoError(t, err)
+
+			err = client.Apply(ctx, collection, nil, false)
+			assert.EqualError(t, err, "plan is required")
+
+			_, err = clientThatErrors.Plan(ctx, collection, desired, nil)
+			assert.ErrorIs(t, err, errSendRequest)
+
+			err = clientThatErrors.Apply(ctx, collection, plan, true)
+			assert.ErrorIs(t, err, errSendRequest)
+		})
+
+		t.Run("schema batch", func(t *testing.T) {
+			batch := NewSchemaBatch().
+				AddFieldTypes(FieldType{Name: "pfloat", Class: "solr.FloatPointField"}).
+				AddFields(Field{Name: "price", Type: "pfloat"}).
+				AddCopyFields(CopyField{Source: "price", Dest: "_text_"})
+
+			expectBody := `{"add-field-type":{"name":"pfloat","class":"solr.FloatPointField"},` +
+				`"add-field":{"name":"price","type":"pfloat"},` +
+				`"add-copy-field":{"source":"price","dest":"_text_"}}`
+			status, respBody := http.StatusOK, `{}`
+			httpmock.RegisterResponder(
+				http.MethodPost,
+				baseURL+"/solr/"+collection+"/schema",
+				func(r *http.Request) (*http.Response, error) {
+					b, err := io.ReadAll(r.Body)
+					if err != nil {
+						return nil, err
+					}
+
+					if strings.TrimSpace(string(b)) != expectBody {
+						return nil, fmt.Errorf("unexpected request body: %s", b)
+					}
+
+					return httpmock.NewStringResponse(status, respBody), nil
+				},
+			)
+
+			err := client.ModifySchema(ctx, collection, batch)
+			require.NoError(t, err)
+
+			status, respBody = http.StatusBadRequest, `{"error":{"code":400,"msg":"error processing commands",
+				"details":[{"add-copy-field":{"source":"price","dest":"_text_"},
+					"errorMessages":["Destination field '_text_' is not a glob and doesn't match any explicit field or dynamicField.\n"]}]}}`
+			err = client.ModifySchema(ctx, collection, batch)
+			var respErr *ResponseError
+			require.ErrorAs(t, err, &respErr)
+			require.Len(t, respErr.Details, 1)
+			assert.Equal(t, "add-copy-field", respErr.Details[0].Command)
+			assert.Len(t, respErr.Details[0].ErrorMessages, 1)
+
+			err = client.ModifySchema(ctx, collection, NewSchemaBatch())
+			require.NoError(t, err)
+
+			err = client.ModifySchema(ctx, collection, nil)
+			assert.EqualError(t, err, "batch is required")
+
+			err = clientThatErrors.ModifySchema(ctx, collection, batch)
+			assert.ErrorIs(t, err, errSendRequest)
+		})
+
 		t.Run("add fields", func(t *testing.T) {
 			mockBody := `{"add-field":[{"name":"foo","type":"string"},{"name":"bar","type":"string"}]}`
 			httpmock.RegisterResponder(
@@ -297,7 +858,7 @@ func TestJSONClientMock(t *testing.T) {
 		})
 
 		t.Run("add dynamic fields", func(t *testing.T) {
-			mockBody := `{"add-dynamic-field":[{"name":"*_foo","type":"string","stored":true},{"name":"*_bar","type":"plong"}]}`
+			mockBody := `{"add-dynamic-field":[{"name":"*_foo","type":"string","stored":true},{"name":"*_bar","type":"plong","stored":false}]}`
 			httpmock.RegisterResponder(
 				http.MethodPost,
 				baseURL+"/solr/"+collection+"/schema",
@@ -308,12 +869,12 @@ func TestJSONClientMock(t *testing.T) {
 				{
 					Name:   "*_foo",
 					Type:   "string",
-					Stored: true,
+					Stored: Bool(true),
 				},
 				{
 					Name:   "*_bar",
 					Type:   "plong",
-					Stored: false,
+					Stored: Bool(false),
 				},
 			}
 			err := client.AddDynamicFields(ctx, collection, fields...)
@@ -590,6 +1151,332 @@ func TestJSONClientMock(t *testing.T) {
 		assert.ErrorIs(t, err, errSendRequest)
 	})
 
+	t.Run("more like this", func(t *testing.T) {
+		httpmock.RegisterResponder(
+			http.MethodGet,
+			baseURL+"/solr/"+collection+"/mlt",
+			func(r *http.Request) (*http.Response, error) {
+				query := "mlt.fl=name&q=%7B%21term+f%3Did%7DSP2514N"
+				gotQuery := r.URL.Query().Encode()
+				if gotQuery != query {
+					return nil, fmt.Errorf("expecting url query to be %q but got %q", query, gotQuery)
+				}
+
+				return httpmock.NewStringResponse(http.StatusOK,
+					`{"response":{"numFound":1,"start":0,"docs":[{"id":"6H500F0"}]}}`), nil
+			},
+		)
+
+		httpmock.RegisterResponder(
+			http.MethodPost,
+			baseURL+"/solr/"+collection+"/mlt",
+			func(r *http.Request) (*http.Response, error) {
+				b, err := io.ReadAll(r.Body)
+				if err != nil {
+					return nil, err
+				}
+
+				if string(b) != "hard drive" || r.Header.Get("content-type") != "text/plain" {
+					return nil, fmt.Errorf("unexpected stream body %q", string(b))
+				}
+
+				return httpmock.NewStringResponse(http.StatusOK,
+					`{"response":{"numFound":1,"start":0,"docs":[{"id":"SP2514N"}]},"interestingTerms":["name:hard","name:drive"]}`), nil
+			},
+		)
+
+		params := NewMoreLikeThisParams().ID("SP2514N").Fields("name")
+		resp, err := client.MoreLikeThis(ctx, collection, params)
+		require.NoError(t, err)
+		assert.Equal(t, []M{{"id": "6H500F0"}}, resp.Response.Documents)
+
+		resp, err = client.MoreLikeThis(ctx, collection, NewMoreLikeThisParams().
+			Stream("hard drive").Fields("name").InterestingTerms("list"))
+		require.NoError(t, err)
+		assert.Len(t, resp.InterestingTerms, 2)
+
+		_, err = clientThatErrors.MoreLikeThis(ctx, collection, params)
+		assert.ErrorIs(t, err, errSendRequest)
+	})
+
+	t.Run("learning to rank", func(t *testing.T) {
+		features := []LTRFeature{
+			{
+				Name:   "documentRecency",
+				Class:  SolrFeatureClass,
+				Params: M{"q": "{!func}recip( ms(NOW,last_modified), 3.16e-11, 1, 1)"},
+				Store:  "myStore",
+			},
+			{
+				Name:   "originalScore",
+				Class:  OriginalScoreFeatureClass,
+				Params: M{},
+				Store:  "myStore",
+			},
+		}
+
+		featureStoreURL := baseURL + "/solr/" + collection + "/schema/feature-store"
+		httpmock.RegisterResponder(
+			http.MethodPut,
+			featureStoreURL,
+			newResponder(`[{"name":"documentRecency","class":"org.apache.solr.ltr.feature.SolrFeature","params":{"q":"{!func}recip( ms(NOW,last_modified), 3.16e-11, 1, 1)"},"store":"myStore"},{"name":"originalScore","class":"org.apache.solr.ltr.feature.OriginalScoreFeature","store":"myStore"}]`, M{}),
+		)
+		httpmock.RegisterResponder(
+			http.MethodGet,
+			featureStoreURL,
+			httpmock.NewStringResponder(http.StatusOK, `{"featureStores":["_DEFAULT_","myStore"]}`),
+		)
+		httpmock.RegisterResponder(
+			http.MethodGet,
+			featureStoreURL+"/myStore",
+			httpmock.NewStringResponder(http.StatusOK, `{"features":[{"name":"originalScore","class":"org.apache.solr.ltr.feature.OriginalScoreFeature","params":null,"store":"myStore"}]}`),
+		)
+		httpmock.RegisterResponder(
+			http.MethodDelete,
+			featureStoreURL+"/myStore",
+			httpmock.NewStringResponder(http.StatusOK, `{}`),
+		)
+
+		err := client.UploadFeatures(ctx, collection, features...)
+		require.NoError(t, err)
+
+		stores, err := client.ListFeatureStores(ctx, collection)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"_DEFAULT_", "myStore"}, stores)
+
+		gotFeatures, err := client.ListFeatures(ctx, collection, "myStore")
+		require.NoError(t, err)
+		assert.Equal(t, []LTRFeature{{Name: "originalScore", Class: OriginalScoreFeatureClass, Store: "myStore"}}, gotFeatures)
+
+		err = client.DeleteFeatureStore(ctx, collection, "myStore")
+		require.NoError(t, err)
+
+		modelStoreURL := baseURL + "/solr/" + collection + "/schema/model-store"
+		model := NewLinearModel("myModel", "myStore", map[string]float64{
+			"documentRecency": 1.0,
+			"originalScore":   0.5,
+		})
+		httpmock.RegisterResponder(
+			http.MethodPut,
+			modelStoreURL,
+			newResponder(`[{"name":"myModel","class":"org.apache.solr.ltr.model.LinearModel","store":"myStore","features":[{"name":"documentRecency"},{"name":"originalScore"}],"params":{"weights":{"documentRecency":1,"originalScore":0.5}}}]`, M{}),
+		)
+		httpmock.RegisterResponder(
+			http.MethodGet,
+			modelStoreURL,
+			httpmock.NewStringResponder(http.StatusOK, `{"models":[{"name":"myModel","class":"org.apache.solr.ltr.model.LinearModel","store":"myStore","features":[{"name":"documentRecency","norm":{"class":"org.apache.solr.ltr.norm.IdentityNormalizer"}},{"name":"originalScore","norm":{"class":"org.apache.solr.ltr.norm.IdentityNormalizer"}}],"params":{"weights":{"documentRecency":1.0,"originalScore":0.5}}}]}`),
+		)
+		httpmock.RegisterResponder(
+			http.MethodDelete,
+			modelStoreURL+"/myModel",
+			httpmock.NewStringResponder(http.StatusOK, `{}`),
+		)
+		httpmock.RegisterResponder(
+			http.MethodDelete,
+			modelStoreURL+"/missing",
+			httpmock.NewStringResponder(http.StatusNotFound, `{"error":{"code":404,"msg":"No model named 'missing' found"}}`),
+		)
+
+		err = client.UploadModels(ctx, collection, model)
+		require.NoError(t, err)
+
+		models, err := client.ListModels(ctx, collection)
+		require.NoError(t, err)
+		require.Len(t, models, 1)
+		assert.Equal(t, "myModel", models[0].Name)
+		assert.Equal(t, "org.apache.solr.ltr.norm.IdentityNormalizer", models[0].Features[0].Norm.Class)
+
+		err = client.DeleteModel(ctx, collection, "myModel")
+		require.NoError(t, err)
+
+		err = client.DeleteModel(ctx, collection, "missing")
+		var respErr *ResponseError
+		require.ErrorAs(t, err, &respErr)
+		assert.Equal(t, 404, respErr.Code)
+
+		_, err = clientThatErrors.ListFeatureStores(ctx, collection)
+		assert.ErrorIs(t, err, errSendRequest)
+
+		_, err = clientThatErrors.ListFeatures(ctx, collection, "myStore")
+		assert.ErrorIs(t, err, errSendRequest)
+
+		_, err = clientThatErrors.ListModels(ctx, collection)
+		assert.ErrorIs(t, err, errSendRequest)
+
+		err = clientThatErrors.DeleteModel(ctx, collection, "myModel")
+		assert.ErrorIs(t, err, errSendRequest)
+	})
+
+	t.Run("stream tuples", func(t *testing.T) {
+		expr := SearchExpr(collection, "*:*", "id,price", "id asc")
+		responses := map[string]*http.Response{
+			"ok": httpmock.NewStringResponse(http.StatusOK,
+				`{"result-set":{"docs":[{"id":"1","price":1.5},{"id":"2","price":3},{"EOF":true,"RESPONSE_TIME":3}]}}`),
+			"exception": httpmock.NewStringResponse(http.StatusOK,
+				`{"result-set":{"docs":[{"id":"1"},{"EXCEPTION":"field price is not docValues","EOF":true}]}}`),
+			"error": httpmock.NewStringResponse(http.StatusBadRequest,
+				`{"error":{"code":400,"msg":"invalid expression"}}`),
+			"invalid": httpmock.NewStringResponse(http.StatusOK, `{"result-set":{"docs":{}}}`),
+			"truncated": httpmock.NewStringResponse(http.StatusOK,
+				`{"result-set":{"docs":[{"id":"1"},{"id":"2"}`),
+			"truncated-tuple": httpmock.NewStringResponse(http.StatusOK,
+				`{"result-set":{"docs":[{"id":"1"},{"id":`),
+			"no-eof": httpmock.NewStringResponse(http.StatusOK,
+				`{"result-set":{"docs":[{"id":"1"}]}}`),
+			"truncated-end": httpmock.NewStringResponse(http.StatusOK,
+				`{"result-set":{"docs":[{"id":"1"},{"EOF":true}`),
+			"malformed": httpmock.NewStringResponse(http.StatusOK,
+				`{"result-set":{"docs":[{"id":"1"}x`),
+		}
+
+		for name, resp := range responses {
+			resp := resp
+			httpmock.RegisterResponder(
+				http.MethodPost,
+				baseURL+"/solr/"+name+"/stream",
+				func(r *http.Request) (*http.Response, error) {
+					err := r.ParseForm()
+					if err != nil {
+						return nil, err
+					}
+
+					if r.Header.Get("content-type") != Form.String() {
+						return nil, fmt.Errorf("unexpected content type %q", r.Header.Get("content-type"))
+					}
+
+					if r.PostForm.Get("expr") != expr.String() {
+						return nil, fmt.Errorf("unexpected expr %q", r.PostForm.Get("expr"))
+					}
+
+					return resp, nil
+				},
+			)
+		}
+
+		stream, err := client.StreamTuples(ctx, "ok", expr)
+		require.NoError(t, err)
+
+		tuples := []M{}
+		for stream.Next() {
+			tuples = append(tuples, stream.Tuple())
+		}
+		require.NoError(t, stream.Err())
+		assert.Equal(t, []M{{"id": "1", "price": json.Number("1.5")}, {"id": "2", "price": json.Number("3")}}, tuples)
+		assert.False(t, stream.Next())
+		assert.NoError(t, stream.Close())
+
+		stream, err = client.StreamTuples(ctx, "exception", expr)
+		require.NoError(t, err)
+		assert.True(t, stream.Next())
+		assert.False(t, stream.Next())
+		var streamErr *StreamError
+		require.ErrorAs(t, stream.Err(), &streamErr)
+		assert.Equal(t, "field price is not docValues", streamErr.Msg)
+
+		_, err = client.StreamTuples(ctx, "error", expr)
+		var respErr *ResponseError
+		require.ErrorAs(t, err, &respErr)
+		assert.Equal(t, "invalid expression", respErr.Msg)
+
+		_, err = client.StreamTuples(ctx, "invalid", expr)
+		assert.Error(t, err)
+
+		for _, name := range []string{"truncated", "truncated-tuple", "no-eof", "truncated-end"} {
+			stream, err = client.StreamTuples(ctx, name, expr)
+			require.NoError(t, err)
+
+			tuples := 0
+			for stream.Next() {
+				tuples++
+			}
+			assert.ErrorIs(t, stream.Err(), io.ErrUnexpectedEOF, name)
+			assert.GreaterOrEqual(t, tuples, 1, name)
+		}
+
+		stream, err = client.StreamTuples(ctx, "malformed", expr)
+		require.NoError(t, err)
+		assert.True(t, stream.Next())
+		assert.False(t, stream.Next())
+		var syntaxErr *json.SyntaxError
+		assert.ErrorAs(t, stream.Err(), &syntaxErr)
+		assert.NotErrorIs(t, stream.Err(), io.ErrUnexpectedEOF)
+
+		_, err = clientThatErrors.StreamTuples(ctx, collection, expr)
+		assert.ErrorIs(t, err, errSendRequest)
+
+		_, err = clientThatErrors.SQL(ctx, collection, "SELECT id FROM "+collection, nil)
+		assert.ErrorIs(t, err, errSendRequest)
+	})
+
+	t.Run("export", func(t *testing.T) {
+		truncated := false
+		httpmock.RegisterResponder(
+			http.MethodGet,
+			baseURL+"/solr/"+collection+"/schema/fields",
+			func(r *http.Request) (*http.Response, error) {
+				gotQuery := r.URL.Query()
+				if gotQuery.Get("includeDynamic") != "true" || gotQuery.Get("showDefaults") != "true" {
+					return nil, fmt.Errorf("unexpected url query %q", gotQuery.Encode())
+				}
+
+				return httpmock.NewStringResponse(http.StatusOK, `{"fields":[
+					{"name":"id","type":"string","docValues":true},
+					{"name":"price_f","type":"pfloat","docValues":true,"dynamicBase":"*_f"},
+					{"name":"name","type":"text_general","docValues":false}]}`), nil
+			},
+		)
+
+		httpmock.RegisterResponder(
+			http.MethodGet,
+			baseURL+"/solr/"+collection+"/export",
+			func(r *http.Request) (*http.Response, error) {
+				query := "fl=id%2Cprice_f&q=%2A%3A%2A&sort=id+asc"
+				gotQuery := r.URL.Query().Encode()
+				if gotQuery != query {
+					return nil, fmt.Errorf("expecting url query to be %q but got %q", query, gotQuery)
+				}
+
+				body := `{"responseHeader":{"status":0},
+					"response":{"numFound":2,"docs":[{"id":"1","price_f":1.5},{"id":"2","price_f":2.5}]}}`
+				if truncated {
+					body = body[:len(body)-3]
+				}
+
+				return httpmock.NewStringResponse(http.StatusOK, body), nil
+			},
+		)
+
+		stream, err := client.Export(ctx, collection, "*:*", "id asc", "id", "price_f")
+		require.NoError(t, err)
+
+		docs := []M{}
+		for stream.Next() {
+			docs = append(docs, stream.Tuple())
+		}
+		require.NoError(t, stream.Err())
+		assert.Equal(t, []M{{"id": "1", "price_f": json.Number("1.5")}, {"id": "2", "price_f": json.Number("2.5")}}, docs)
+
+		truncated = true
+		stream, err = client.Export(ctx, collection, "*:*", "id asc", "id", "price_f")
+		require.NoError(t, err)
+		for stream.Next() {
+		}
+		assert.ErrorIs(t, stream.Err(), io.ErrUnexpectedEOF)
+		truncated = false
+
+		_, err = client.Export(ctx, collection, "*:*", "id asc", "id", "price_f", "name")
+		assert.EqualError(t, err, `field "name" does not have docValues`)
+
+		_, err = client.Export(ctx, collection, "*:*", "missing asc", "id", "price_f")
+		assert.EqualError(t, err, `field "missing" not found`)
+
+		_, err = client.Export(ctx, collection, "*:*", "", "id")
+		assert.Error(t, err)
+
+		_, err = clientThatErrors.Export(ctx, collection, "*:*", "id asc", "id")
+		assert.ErrorIs(t, err, errSendRequest)
+	})
+
 	t.Run("unexpected html", func(t *testing.T) {
 		httpmock.RegisterResponder(http.MethodGet, baseURL+"/solr/admin/cores", func(r *http.Request) (*http.Response, error) {
 			response := httpmock.NewBytesResponse(http.StatusUnauthorized, []byte("<html><title>Unauthorized</html>"))
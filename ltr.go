@@ -0,0 +1,326 @@
+package solr
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Learning to rank feature classes
+const (
+	SolrFeatureClass          = "org.apache.solr.ltr.feature.SolrFeature"
+	FieldValueFeatureClass    = "org.apache.solr.ltr.feature.FieldValueFeature"
+	FieldLengthFeatureClass   = "org.apache.solr.ltr.feature.FieldLengthFeature"
+	OriginalScoreFeatureClass = "org.apache.solr.ltr.feature.OriginalScoreFeature"
+	ValueFeatureClass         = "org.apache.solr.ltr.feature.ValueFeature"
+)
+
+// Learning to rank model classes
+const (
+	LinearModelClass                = "org.apache.solr.ltr.model.LinearModel"
+	MultipleAdditiveTreesModelClass = "org.apache.solr.ltr.model.MultipleAdditiveTreesModel"
+)
+
+// LTRFeature is a learning to rank feature in the feature store
+//
+// Refer to https://solr.apache.org/guide/8_8/learning-to-rank.html#uploading-features
+type LTRFeature struct {
+	Name   string `json:"name"`
+	Class  string `json:"class"`
+	Params M      `json:"params,omitempty"`
+	// Store is the feature store, defaults to _DEFAULT_
+	Store string `json:"store,omitempty"`
+}
+
+// LTRModel is a learning to rank model in the model store
+//
+// Refer to https://solr.apache.org/guide/8_8/learning-to-rank.html#uploading-a-model
+type LTRModel struct {
+	Name     string            `json:"name"`
+	Class    string            `json:"class"`
+	Store    string            `json:"store,omitempty"`
+	Features []LTRModelFeature `json:"features"`
+	Params   M                 `json:"params,omitempty"`
+}
+
+// LTRModelFeature is a feature used by a model with its optional normalizer
+type LTRModelFeature struct {
+	Name string         `json:"name"`
+	Norm *LTRNormalizer `json:"norm,omitempty"`
+}
+
+// LTRNormalizer is a feature normalizer
+type LTRNormalizer struct {
+	Class  string `json:"class"`
+	Params M      `json:"params,omitempty"`
+}
+
+// NewLinearModel returns a new linear model with the feature weights
+func NewLinearModel(name, store string, weights map[string]float64) LTRModel {
+	names := make([]string, 0, len(weights))
+	for name := range weights {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	features := make([]LTRModelFeature, 0, len(names))
+	for _, name := range names {
+		features = append(features, LTRModelFeature{Name: name})
+	}
+
+	return LTRModel{
+		Name:     name,
+		Class:    LinearModelClass,
+		Store:    store,
+		Features: features,
+		Params:   M{"weights": weights},
+	}
+}
+
+// LTRTree is a weighted tree of a multiple additive trees model
+type LTRTree struct {
+	Weight float64
+	Root   *LTRTreeNode
+}
+
+// LTRTreeNode is a node of a tree. A branch compares the feature value with
+// the threshold, going left if it is less than or equal to the threshold,
+// while a leaf (Left and Right are nil) holds the value.
+type LTRTreeNode struct {
+	Feature   string
+	Threshold float64
+	Left      *LTRTreeNode
+	Right     *LTRTreeNode
+	Value     float64
+}
+
+var (
+	_ json.Marshaler   = LTRTree{}
+	_ json.Marshaler   = (*LTRTreeNode)(nil)
+	_ json.Unmarshaler = (*LTRTree)(nil)
+	_ json.Unmarshaler = (*LTRTreeNode)(nil)
+)
+
+// MarshalJSON implements json.Marshaler
+func (t LTRTree) MarshalJSON() ([]byte, error) {
+	return json.Marshal(M{"weight": formatFloat(t.Weight), "root": t.Root})
+}
+
+// UnmarshalJSON implements json.Unmarshaler
+func (t *LTRTree) UnmarshalJSON(b []byte) error {
+	var raw struct {
+		Weight json.Number  `json:"weight"`
+		Root   *LTRTreeNode `json:"root"`
+	}
+	err := json.Unmarshal(b, &raw)
+	if err != nil {
+		return err
+	}
+
+	t.Root = raw.Root
+	t.Weight, err = parseNumber(raw.Weight)
+	return err
+}
+
+// MarshalJSON implements json.Marshaler
+func (n *LTRTreeNode) MarshalJSON() ([]byte, error) {
+	if n.Left == nil && n.Right == nil {
+		return json.Marshal(M{"value": formatFloat(n.Value)})
+	}
+
+	return json.Marshal(M{
+		"feature":   n.Feature,
+		"threshold": formatFloat(n.Threshold),
+		"left":      n.Left,
+		"right":     n.Right,
+	})
+}
+
+// UnmarshalJSON implements json.Unmarshaler
+func (n *LTRTreeNode) UnmarshalJSON(b []byte) error {
+	var raw struct {
+		Feature   string       `json:"feature"`
+		Threshold json.Number  `json:"threshold"`
+		Left      *LTRTreeNode `json:"left"`
+		Right     *LTRTreeNode `json:"right"`
+		Value     json.Number  `json:"value"`
+	}
+	err := json.Unmarshal(b, &raw)
+	if err != nil {
+		return err
+	}
+
+	n.Feature, n.Left, n.Right = raw.Feature, raw.Left, raw.Right
+	n.Threshold, err = parseNumber(raw.Threshold)
+	if err != nil {
+		return wrapErr(err, "parse threshold")
+	}
+
+	n.Value, err = parseNumber(raw.Value)
+	if err != nil {
+		return wrapErr(err, "parse value")
+	}
+
+	return nil
+}
+
+// parseNumber parses a number that is encoded either as a json number or as a string
+func parseNumber(n json.Number) (float64, error) {
+	if n == "" {
+		return 0, nil
+	}
+
+	return strconv.ParseFloat(string(n), 64)
+}
+
+// NewMultipleAdditiveTreesModel returns a new multiple additive trees model
+func NewMultipleAdditiveTreesModel(name, store string, features []string, trees []LTRTree) LTRModel {
+	modelFeatures := make([]LTRModelFeature, 0, len(features))
+	for _, feature := range features {
+		modelFeatures = append(modelFeatures, LTRModelFeature{Name: feature})
+	}
+
+	return LTRModel{
+		Name:     name,
+		Class:    MultipleAdditiveTreesModelClass,
+		Store:    store,
+		Features: modelFeatures,
+		Params:   M{"trees": trees},
+	}
+}
+
+// FeaturesTransformer is the [features] document transformer
+// which returns the feature values of each document
+//
+// Refer to https://solr.apache.org/guide/8_8/learning-to-rank.html#extracting-features
+type FeaturesTransformer struct {
+	store  string
+	format string
+	efi    map[string]string
+}
+
+// NewFeaturesTransformer returns a new FeaturesTransformer
+func NewFeaturesTransformer() *FeaturesTransformer {
+	return &FeaturesTransformer{efi: map[string]string{}}
+}
+
+// Store sets the feature store, defaults to the store of the model of the
+// rerank query or to _DEFAULT_ if there is none
+func (ft *FeaturesTransformer) Store(store string) *FeaturesTransformer {
+	ft.store = store
+	return ft
+}
+
+// Format sets the format of the features (dense or sparse)
+func (ft *FeaturesTransformer) Format(format string) *FeaturesTransformer {
+	ft.format = format
+	return ft
+}
+
+// Efi sets an external feature information param (efi.key=value)
+func (ft *FeaturesTransformer) Efi(key, value string) *FeaturesTransformer {
+	ft.efi[key] = value
+	return ft
+}
+
+// BuildTransformer builds the transformer, which can be
+// included in the fields (fl) of the Query
+func (ft *FeaturesTransformer) BuildTransformer() string {
+	kv := []string{"features"}
+
+	if ft.store != "" {
+		kv = append(kv, fmt.Sprintf("store=%s", quoteLocalParam(ft.store)))
+	}
+
+	if ft.format != "" {
+		kv = append(kv, fmt.Sprintf("format=%s", quoteLocalParam(ft.format)))
+	}
+
+	return fmt.Sprintf("[%s]", strings.Join(append(kv, buildEfi(ft.efi)...), " "))
+}
+
+// FeaturesField is the name of the field of the [features] transformer
+const FeaturesField = "[features]"
+
+// FeatureValue is a feature value of a document
+type FeatureValue struct {
+	Name  string
+	Value float64
+}
+
+// FeatureVector is the list of feature values of a document
+type FeatureVector []FeatureValue
+
+// Get returns the value of the feature, the second return
+// value is false if the feature is not in the vector
+func (fv FeatureVector) Get(name string) (float64, bool) {
+	for _, v := range fv {
+		if v.Name == name {
+			return v.Value, true
+		}
+	}
+
+	return 0, false
+}
+
+// ParseFeatureVector parses the feature values in the default
+// csv format of the [features] transformer e.g. "a=1.0,b=0.5".
+// The format has no escaping, so feature names must not contain
+// commas or equal signs, otherwise the pairs are split wrongly.
+func ParseFeatureVector(s string) (FeatureVector, error) {
+	fv := FeatureVector{}
+	if strings.TrimSpace(s) == "" {
+		return fv, nil
+	}
+
+	for _, pair := range strings.Split(s, ",") {
+		kv := strings.SplitN(pair, "=", 2)
+		if len(kv) != 2 {
+			return nil, fmt.Errorf("invalid feature %q", pair)
+		}
+
+		val, err := strconv.ParseFloat(strings.TrimSpace(kv[1]), 64)
+		if err != nil {
+			return nil, wrapErr(err, "parse feature "+kv[0])
+		}
+
+		fv = append(fv, FeatureValue{Name: strings.TrimSpace(kv[0]), Value: val})
+	}
+
+	return fv, nil
+}
+
+// DocumentFeatures returns the feature vector of the [features] field of the document
+func DocumentFeatures(doc M) (FeatureVector, error) {
+	v, ok := doc[FeaturesField]
+	if !ok {
+		return nil, fmt.Errorf("document has no %s field", FeaturesField)
+	}
+
+	s, ok := v.(string)
+	if !ok {
+		return nil, fmt.Errorf("expecting %s to be a string but got %T", FeaturesField, v)
+	}
+
+	return ParseFeatureVector(s)
+}
+
+// listFeatureStoresResponse is the feature store list response
+type listFeatureStoresResponse struct {
+	*BaseResponse
+	FeatureStores []string `json:"featureStores"`
+}
+
+// listFeaturesResponse is the feature list response
+type listFeaturesResponse struct {
+	*BaseResponse
+	Features []LTRFeature `json:"features"`
+}
+
+// listModelsResponse is the model list response
+type listModelsResponse struct {
+	*BaseResponse
+	Models []LTRModel `json:"models"`
+}
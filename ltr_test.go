@@ -0,0 +1,127 @@
+package solr_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/stevenferrer/solr-go"
+)
+
+func TestLTRModels(t *testing.T) {
+	t.Run("linear model", func(t *testing.T) {
+		model := solr.NewLinearModel("myModel", "myStore", map[string]float64{
+			"originalScore":   0.5,
+			"documentRecency": 1,
+		})
+
+		b, err := json.Marshal(model)
+		require.NoError(t, err)
+
+		expect := `{"name":"myModel","class":"org.apache.solr.ltr.model.LinearModel","store":"myStore",
+			"features":[{"name":"documentRecency"},{"name":"originalScore"}],
+			"params":{"weights":{"documentRecency":1,"originalScore":0.5}}}`
+		assert.JSONEq(t, expect, string(b))
+	})
+
+	t.Run("multiple additive trees model", func(t *testing.T) {
+		trees := []solr.LTRTree{
+			{
+				Weight: 1,
+				Root: &solr.LTRTreeNode{
+					Feature:   "userTextTitleMatch",
+					Threshold: 0.5,
+					Left:      &solr.LTRTreeNode{Value: -100},
+					Right: &solr.LTRTreeNode{
+						Feature:   "originalScore",
+						Threshold: 10,
+						Left:      &solr.LTRTreeNode{Value: 50},
+						Right:     &solr.LTRTreeNode{Value: 75},
+					},
+				},
+			},
+		}
+		model := solr.NewMultipleAdditiveTreesModel("myTreeModel", "",
+			[]string{"userTextTitleMatch", "originalScore"}, trees)
+
+		b, err := json.Marshal(model)
+		require.NoError(t, err)
+
+		expect := `{"name":"myTreeModel","class":"org.apache.solr.ltr.model.MultipleAdditiveTreesModel",
+			"features":[{"name":"userTextTitleMatch"},{"name":"originalScore"}],
+			"params":{"trees":[{"weight":"1","root":{"feature":"userTextTitleMatch","threshold":"0.5",
+				"left":{"value":"-100"},
+				"right":{"feature":"originalScore","threshold":"10","left":{"value":"50"},"right":{"value":"75"}}}}]}}`
+		assert.JSONEq(t, expect, string(b))
+
+		var gotTrees []solr.LTRTree
+		err = json.Unmarshal([]byte(`[{"weight":"1","root":{"feature":"userTextTitleMatch","threshold":0.5,
+			"left":{"value":"-100"},
+			"right":{"feature":"originalScore","threshold":"10","left":{"value":50},"right":{"value":"75"}}}}]`), &gotTrees)
+		require.NoError(t, err)
+		assert.Equal(t, trees, gotTrees)
+
+		err = json.Unmarshal([]byte(`[{"weight":"x"}]`), &gotTrees)
+		assert.Error(t, err)
+	})
+}
+
+func TestFeaturesTransformer(t *testing.T) {
+	got := solr.NewFeaturesTransformer().BuildTransformer()
+	assert.Equal(t, "[features]", got)
+
+	got = solr.NewFeaturesTransformer().
+		Store("myStore").
+		Format("sparse").
+		Efi("user_query", "hard drive").
+		Efi("user_id", "u1").
+		BuildTransformer()
+	assert.Equal(t, "[features store=myStore format=sparse efi.user_id=u1 efi.user_query='hard drive']", got)
+
+	got = solr.NewFeaturesTransformer().Store("my store").BuildTransformer()
+	assert.Equal(t, "[features store='my store']", got)
+}
+
+func TestFeatureVector(t *testing.T) {
+	fv, err := solr.DocumentFeatures(solr.M{"id": "1", "[features]": "documentRecency=0.9,originalScore=1.5"})
+	require.NoError(t, err)
+	assert.Equal(t, solr.FeatureVector{
+		{Name: "documentRecency", Value: 0.9},
+		{Name: "originalScore", Value: 1.5},
+	}, fv)
+
+	val, ok := fv.Get("originalScore")
+	assert.True(t, ok)
+	assert.Equal(t, 1.5, val)
+
+	_, ok = fv.Get("missing")
+	assert.False(t, ok)
+
+	fv, err = solr.ParseFeatureVector("")
+	require.NoError(t, err)
+	assert.Empty(t, fv)
+
+	_, err = solr.ParseFeatureVector("a=1,b")
+	assert.Error(t, err)
+
+	_, err = solr.ParseFeatureVector("a=x")
+	assert.Error(t, err)
+
+	_, err = solr.DocumentFeatures(solr.M{"id": "1"})
+	assert.Error(t, err)
+
+	_, err = solr.DocumentFeatures(solr.M{"[features]": 1})
+	assert.Error(t, err)
+}
+
+func TestQueryReRank(t *testing.T) {
+	rq := solr.NewLTRQueryParser("myModel").ReRankDocs(100).BuildParser()
+	query := solr.NewQuery("*:*").
+		ReRank(rq).
+		Fields("id", "score", solr.NewFeaturesTransformer().BuildTransformer()).
+		BuildQuery()
+	assert.Equal(t, solr.M{"rq": "{!ltr model=myModel reRankDocs=100}"}, query["params"])
+	assert.Equal(t, []string{"id", "score", "[features]"}, query["fields"])
+}
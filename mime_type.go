@@ -8,6 +8,8 @@ const (
 	JSON MimeType = iota
 	XML
 	CSV
+	Text
+	Form
 )
 
 // String implements Stringer
@@ -16,5 +18,7 @@ func (mt MimeType) String() string {
 		"application/json",
 		"application/xml",
 		"text/csv",
+		"text/plain",
+		"application/x-www-form-urlencoded",
 	}[mt]
 }
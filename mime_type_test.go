@@ -25,6 +25,14 @@ func TestContentTypeStringer(t *testing.T) {
 			solr.CSV,
 			"text/csv",
 		},
+		{
+			solr.Text,
+			"text/plain",
+		},
+		{
+			solr.Form,
+			"application/x-www-form-urlencoded",
+		},
 	}
 
 	for _, test := range tests {
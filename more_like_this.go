@@ -0,0 +1,287 @@
+package solr
+
+import (
+	"encoding/json"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// MoreLikeThisParams is the more like this (/mlt handler) param builder
+//
+// Refer to https://solr.apache.org/guide/8_8/morelikethis.html#using-the-morelikethishandler
+type MoreLikeThisParams struct {
+	endpoint string
+
+	// id is the unique key of the document to find similar documents for
+	id        string
+	uniqueKey string
+	// q is the query of the document to find similar documents for
+	q string
+	// stream is the text to find similar documents for
+	stream string
+
+	fields           []string // mlt.fl
+	qf               []string // mlt.qf
+	minTF            int
+	minDF            int
+	maxDF            int
+	maxQT            int
+	boost            bool
+	interestingTerms string
+	matchInclude     bool
+	matchOffset      int
+
+	start     int
+	rows      int
+	filters   []string // fq
+	fieldList []string // fl
+}
+
+// NewMoreLikeThisParams returns a new MoreLikeThisParams
+// for the default /mlt endpoint
+func NewMoreLikeThisParams() *MoreLikeThisParams {
+	return &MoreLikeThisParams{endpoint: "mlt", uniqueKey: "id"}
+}
+
+// Endpoint overrides the more like this handler endpoint (default "mlt")
+func (p *MoreLikeThisParams) Endpoint(endpoint string) *MoreLikeThisParams {
+	p.endpoint = endpoint
+	return p
+}
+
+// UniqueKey overrides the unique key field used by ID (default "id")
+func (p *MoreLikeThisParams) UniqueKey(uniqueKey string) *MoreLikeThisParams {
+	p.uniqueKey = uniqueKey
+	return p
+}
+
+// ID finds documents similar to the document with the given unique key
+func (p *MoreLikeThisParams) ID(id string) *MoreLikeThisParams {
+	p.id = id
+	return p
+}
+
+// Query finds documents similar to the first document matching the query
+func (p *MoreLikeThisParams) Query(q string) *MoreLikeThisParams {
+	p.q = q
+	return p
+}
+
+// Stream finds documents similar to the text, which
+// is sent as the body (content stream) of the request
+func (p *MoreLikeThisParams) Stream(text string) *MoreLikeThisParams {
+	p.stream = text
+	return p
+}
+
+// Fields sets the fields to use for similarity (mlt.fl)
+func (p *MoreLikeThisParams) Fields(fields ...string) *MoreLikeThisParams {
+	p.fields = fields
+	return p
+}
+
+// Qf sets the fields to query with their boosts (mlt.qf)
+func (p *MoreLikeThisParams) Qf(qf ...string) *MoreLikeThisParams {
+	p.qf = qf
+	return p
+}
+
+// MinTF sets the minimum term frequency, below which terms are ignored
+func (p *MoreLikeThisParams) MinTF(minTF int) *MoreLikeThisParams {
+	p.minTF = minTF
+	return p
+}
+
+// MinDF sets the minimum document frequency, below which terms are ignored
+func (p *MoreLikeThisParams) MinDF(minDF int) *MoreLikeThisParams {
+	p.minDF = minDF
+	return p
+}
+
+// MaxDF sets the maximum document frequency, above which terms are ignored
+func (p *MoreLikeThisParams) MaxDF(maxDF int) *MoreLikeThisParams {
+	p.maxDF = maxDF
+	return p
+}
+
+// MaxQT sets the maximum number of query terms
+func (p *MoreLikeThisParams) MaxQT(maxQT int) *MoreLikeThisParams {
+	p.maxQT = maxQT
+	return p
+}
+
+// Boost if true, boosts the query terms by their relevance
+func (p *MoreLikeThisParams) Boost(boost bool) *MoreLikeThisParams {
+	p.boost = boost
+	return p
+}
+
+// InterestingTerms sets how the interesting terms
+// are reported (list, details or none)
+func (p *MoreLikeThisParams) InterestingTerms(interestingTerms string) *MoreLikeThisParams {
+	p.interestingTerms = interestingTerms
+	return p
+}
+
+// MatchInclude if true, returns the matched document
+func (p *MoreLikeThisParams) MatchInclude(matchInclude bool) *MoreLikeThisParams {
+	p.matchInclude = matchInclude
+	return p
+}
+
+// MatchOffset sets the offset of the document to
+// use from the results of the query (mlt.match.offset)
+func (p *MoreLikeThisParams) MatchOffset(matchOffset int) *MoreLikeThisParams {
+	p.matchOffset = matchOffset
+	return p
+}
+
+// Start sets the offset of the similar documents
+func (p *MoreLikeThisParams) Start(start int) *MoreLikeThisParams {
+	p.start = start
+	return p
+}
+
+// Rows sets the number of similar documents to return
+func (p *MoreLikeThisParams) Rows(rows int) *MoreLikeThisParams {
+	p.rows = rows
+	return p
+}
+
+// Filters sets the filters (fq) of the similar documents
+func (p *MoreLikeThisParams) Filters(filters ...string) *MoreLikeThisParams {
+	p.filters = filters
+	return p
+}
+
+// FieldList sets the fields (fl) to return for the similar documents
+func (p *MoreLikeThisParams) FieldList(fields ...string) *MoreLikeThisParams {
+	p.fieldList = fields
+	return p
+}
+
+// BuildParams builds the parameters
+func (p *MoreLikeThisParams) BuildParams() string {
+	vals := &url.Values{}
+
+	if p.id != "" {
+		vals.Add("q", "{!term f="+p.uniqueKey+"}"+p.id)
+	} else if p.q != "" {
+		vals.Add("q", p.q)
+	}
+
+	if len(p.fields) > 0 {
+		vals.Add("mlt.fl", strings.Join(p.fields, ","))
+	}
+
+	if len(p.qf) > 0 {
+		vals.Add("mlt.qf", strings.Join(p.qf, " "))
+	}
+
+	if p.minTF > 0 {
+		vals.Add("mlt.mintf", strconv.Itoa(p.minTF))
+	}
+
+	if p.minDF > 0 {
+		vals.Add("mlt.mindf", strconv.Itoa(p.minDF))
+	}
+
+	if p.maxDF > 0 {
+		vals.Add("mlt.maxdf", strconv.Itoa(p.maxDF))
+	}
+
+	if p.maxQT > 0 {
+		vals.Add("mlt.maxqt", strconv.Itoa(p.maxQT))
+	}
+
+	if p.boost {
+		vals.Add("mlt.boost", "true")
+	}
+
+	if p.interestingTerms != "" {
+		vals.Add("mlt.interestingTerms", p.interestingTerms)
+	}
+
+	if p.matchInclude {
+		vals.Add("mlt.match.include", "true")
+	}
+
+	if p.matchOffset > 0 {
+		vals.Add("mlt.match.offset", strconv.Itoa(p.matchOffset))
+	}
+
+	if p.start > 0 {
+		vals.Add("start", strconv.Itoa(p.start))
+	}
+
+	if p.rows > 0 {
+		vals.Add("rows", strconv.Itoa(p.rows))
+	}
+
+	for _, fq := range p.filters {
+		vals.Add("fq", fq)
+	}
+
+	if len(p.fieldList) > 0 {
+		vals.Add("fl", strings.Join(p.fieldList, ","))
+	}
+
+	return vals.Encode()
+}
+
+// MoreLikeThisResponse is the more like this response
+type MoreLikeThisResponse struct {
+	*BaseResponse
+	// Match is the matched document, only available with mlt.match.include
+	Match *QueryResponseBody `json:"match,omitempty"`
+	// Response is the similar documents
+	Response QueryResponseBody `json:"response"`
+	// InterestingTerms is the terms used to find the similar
+	// documents, only available with mlt.interestingTerms
+	InterestingTerms InterestingTerms `json:"interestingTerms,omitempty"`
+}
+
+// InterestingTerm is an interesting term. Boost is
+// only available with mlt.interestingTerms=details
+type InterestingTerm struct {
+	Term  string
+	Boost float64
+}
+
+// InterestingTerms is a list of interesting terms
+type InterestingTerms []InterestingTerm
+
+// UnmarshalJSON implements json.Unmarshaler. It handles the list of
+// terms (list) and the named list of terms and boosts (details).
+func (it *InterestingTerms) UnmarshalJSON(b []byte) error {
+	var terms []string
+	if err := json.Unmarshal(b, &terms); err == nil {
+		list := make(InterestingTerms, 0, len(terms))
+		for _, term := range terms {
+			list = append(list, InterestingTerm{Term: term})
+		}
+
+		*it = list
+		return nil
+	}
+
+	nvs, err := decodeNamedList(b)
+	if err != nil {
+		return err
+	}
+
+	list := make(InterestingTerms, 0, len(nvs))
+	for _, nv := range nvs {
+		term := InterestingTerm{Term: nv.name}
+		err = json.Unmarshal(nv.value, &term.Boost)
+		if err != nil {
+			return wrapErr(err, "decode boost")
+		}
+
+		list = append(list, term)
+	}
+
+	*it = list
+	return nil
+}
@@ -0,0 +1,76 @@
+package solr_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/stevenferrer/solr-go"
+)
+
+func TestBuildMoreLikeThisParams(t *testing.T) {
+	got := solr.NewMoreLikeThisParams().
+		ID("SP2514N").
+		UniqueKey("sku").
+		Fields("name", "features").
+		Qf("name^2", "features").
+		MinTF(1).MinDF(2).MaxDF(100).MaxQT(25).
+		Boost(true).
+		InterestingTerms("details").
+		MatchInclude(true).
+		MatchOffset(1).
+		Start(5).Rows(10).
+		Filters("inStock:true").
+		FieldList("id", "score").
+		BuildParams()
+
+	expect := "fl=id%2Cscore&fq=inStock%3Atrue&mlt.boost=true&mlt.fl=name%2Cfeatures&mlt.interestingTerms=details" +
+		"&mlt.match.include=true&mlt.match.offset=1&mlt.maxdf=100&mlt.maxqt=25&mlt.mindf=2&mlt.mintf=1" +
+		"&mlt.qf=name%5E2+features&q=%7B%21term+f%3Dsku%7DSP2514N&rows=10&start=5"
+	assert.Equal(t, expect, got)
+
+	got = solr.NewMoreLikeThisParams().Query("name:ipod").BuildParams()
+	assert.Equal(t, "q=name%3Aipod", got)
+}
+
+func TestMoreLikeThisResponse(t *testing.T) {
+	t.Run("details", func(t *testing.T) {
+		body := `{
+			"match": {"numFound": 1, "start": 0, "docs": [{"id": "SP2514N"}]},
+			"response": {"numFound": 2, "start": 0, "docs": [{"id": "6H500F0"}, {"id": "F8V7067-APL-KIT"}]},
+			"interestingTerms": ["features:drive", 1.0, "name:hard", 0.5]
+		}`
+
+		var resp solr.MoreLikeThisResponse
+		err := json.Unmarshal([]byte(body), &resp)
+		require.NoError(t, err)
+
+		assert.Equal(t, []solr.M{{"id": "SP2514N"}}, resp.Match.Documents)
+		assert.Equal(t, 2, resp.Response.NumFound)
+		expect := solr.InterestingTerms{
+			{Term: "features:drive", Boost: 1},
+			{Term: "name:hard", Boost: 0.5},
+		}
+		assert.Equal(t, expect, resp.InterestingTerms)
+	})
+
+	t.Run("list", func(t *testing.T) {
+		var resp solr.MoreLikeThisResponse
+		err := json.Unmarshal([]byte(`{"interestingTerms": ["features:drive", "name:hard"]}`), &resp)
+		require.NoError(t, err)
+
+		expect := solr.InterestingTerms{{Term: "features:drive"}, {Term: "name:hard"}}
+		assert.Equal(t, expect, resp.InterestingTerms)
+
+		// a string list is terms only, the boosts of a details list are numbers
+		err = json.Unmarshal([]byte(`{"interestingTerms": ["features:drive", "1.0"]}`), &resp)
+		require.NoError(t, err)
+		expect = solr.InterestingTerms{{Term: "features:drive"}, {Term: "1.0"}}
+		assert.Equal(t, expect, resp.InterestingTerms)
+
+		err = json.Unmarshal([]byte(`{"interestingTerms": {"features:drive": "high"}}`), &resp)
+		assert.Error(t, err)
+	})
+}
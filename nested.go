@@ -0,0 +1,125 @@
+package solr
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ChildDocumentsField is the field used for anonymous (unlabelled) child documents
+const ChildDocumentsField = "_childDocuments_"
+
+// NestedDocument contains the fields that Solr maintains for nested
+// documents. Embed it in a document struct to read them back, Solr
+// returns them only if they are requested in the fields (fl) e.g.
+// "*,_nest_path_,[child]". Nothing is automated by the client: it
+// neither sets nor checks _root_, _nest_path_ and _nest_parent_, Solr
+// sets them on indexing, so leave them empty when writing documents.
+//
+// Child documents are declared as regular struct fields, either labelled
+// (e.g. `json:"skus"`) or anonymous (`json:"_childDocuments_"`).
+//
+// Refer to https://solr.apache.org/guide/8_8/indexing-nested-documents.html
+type NestedDocument struct {
+	// Root is the id of the root document in the block
+	Root string `json:"_root_,omitempty"`
+	// NestPath is the path of the document in the hierarchy
+	NestPath string `json:"_nest_path_,omitempty"`
+	// NestParent is the id of the parent document
+	NestParent string `json:"_nest_parent_,omitempty"`
+}
+
+// ChildDocuments returns the child documents of doc under the label. If the
+// label is empty, the anonymous child documents (_childDocuments_) are returned.
+func ChildDocuments(doc M, label string) []M {
+	if label == "" {
+		label = ChildDocumentsField
+	}
+
+	switch val := doc[label].(type) {
+	case M:
+		return []M{val}
+	case map[string]interface{}:
+		return []M{val}
+	case []M:
+		return val
+	case []interface{}:
+		children := make([]M, 0, len(val))
+		for _, v := range val {
+			switch child := v.(type) {
+			case M:
+				children = append(children, child)
+			case map[string]interface{}:
+				children = append(children, child)
+			}
+		}
+		return children
+	}
+
+	return nil
+}
+
+// ChildTransformer is the [child] document transformer
+// which returns the matching documents with their descendants
+//
+// Refer to https://solr.apache.org/guide/8_8/transforming-result-documents.html#child-childdoctransformerfactory
+type ChildTransformer struct {
+	parentFilter string
+	childFilter  string
+	limit        int
+	fields       []string // fl
+}
+
+// NewChildTransformer returns a new ChildTransformer
+func NewChildTransformer() *ChildTransformer {
+	return &ChildTransformer{}
+}
+
+// ParentFilter sets the parentFilter param, the query that
+// identifies the parent documents in the block
+func (ct *ChildTransformer) ParentFilter(parentFilter string) *ChildTransformer {
+	ct.parentFilter = parentFilter
+	return ct
+}
+
+// ChildFilter sets the childFilter param, the query used
+// to filter the child documents to return
+func (ct *ChildTransformer) ChildFilter(childFilter string) *ChildTransformer {
+	ct.childFilter = childFilter
+	return ct
+}
+
+// Limit sets the maximum number of child documents to return per parent
+func (ct *ChildTransformer) Limit(limit int) *ChildTransformer {
+	ct.limit = limit
+	return ct
+}
+
+// Fields sets the fields (fl) to return for the child documents
+func (ct *ChildTransformer) Fields(fields ...string) *ChildTransformer {
+	ct.fields = fields
+	return ct
+}
+
+// BuildTransformer builds the transformer, which can be
+// included in the fields (fl) of the Query
+func (ct *ChildTransformer) BuildTransformer() string {
+	kv := []string{"child"}
+
+	if ct.parentFilter != "" {
+		kv = append(kv, fmt.Sprintf("parentFilter=%s", quoteLocalParam(ct.parentFilter)))
+	}
+
+	if ct.childFilter != "" {
+		kv = append(kv, fmt.Sprintf("childFilter=%s", quoteLocalParam(ct.childFilter)))
+	}
+
+	if ct.limit != 0 {
+		kv = append(kv, fmt.Sprintf("limit=%d", ct.limit))
+	}
+
+	if len(ct.fields) > 0 {
+		kv = append(kv, fmt.Sprintf("fl=%s", strings.Join(ct.fields, ",")))
+	}
+
+	return fmt.Sprintf("[%s]", strings.Join(kv, " "))
+}
@@ -0,0 +1,118 @@
+package solr_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/stevenferrer/solr-go"
+)
+
+func TestNestedDocuments(t *testing.T) {
+	type sku struct {
+		solr.NestedDocument
+		ID    string `json:"id"`
+		Color string `json:"color_s"`
+	}
+
+	type product struct {
+		solr.NestedDocument
+		ID   string `json:"id"`
+		Name string `json:"name_s"`
+		SKUs []sku  `json:"skus,omitempty"`
+	}
+
+	t.Run("encode", func(t *testing.T) {
+		doc := product{
+			ID:   "P11!prod",
+			Name: "Swingline Stapler",
+			SKUs: []sku{
+				{ID: "P11!S21", Color: "RED"},
+				{ID: "P11!S31", Color: "BLACK"},
+			},
+		}
+
+		b, err := json.Marshal(doc)
+		require.NoError(t, err)
+
+		expect := `{"id":"P11!prod","name_s":"Swingline Stapler","skus":[{"id":"P11!S21","color_s":"RED"},{"id":"P11!S31","color_s":"BLACK"}]}`
+		assert.Equal(t, expect, string(b))
+	})
+
+	t.Run("decode", func(t *testing.T) {
+		var resp solr.QueryResponse
+		err := json.Unmarshal([]byte(`{"response":{"numFound":1,"docs":[{"id":"P11!prod","name_s":"Swingline Stapler","_root_":"P11!prod",`+
+			`"skus":[{"id":"P11!S21","color_s":"RED","_root_":"P11!prod","_nest_path_":"/skus#0","_nest_parent_":"P11!prod"}]}]}}`), &resp)
+		require.NoError(t, err)
+
+		children := solr.ChildDocuments(resp.Response.Documents[0], "skus")
+		require.Len(t, children, 1)
+		assert.Equal(t, "P11!S21", children[0]["id"])
+
+		var products []product
+		err = resp.Response.DecodeDocuments(&products)
+		require.NoError(t, err)
+
+		expect := []product{
+			{
+				NestedDocument: solr.NestedDocument{Root: "P11!prod"},
+				ID:             "P11!prod",
+				Name:           "Swingline Stapler",
+				SKUs: []sku{
+					{
+						NestedDocument: solr.NestedDocument{
+							Root:       "P11!prod",
+							NestPath:   "/skus#0",
+							NestParent: "P11!prod",
+						},
+						ID:    "P11!S21",
+						Color: "RED",
+					},
+				},
+			},
+		}
+		assert.Equal(t, expect, products)
+	})
+}
+
+func TestChildDocuments(t *testing.T) {
+	doc := solr.M{
+		"_childDocuments_": []interface{}{
+			map[string]interface{}{"id": "1"},
+			"not a document",
+		},
+		"manual": solr.M{"id": "2"},
+		"parts":  []solr.M{{"id": "3"}},
+	}
+
+	assert.Equal(t, []solr.M{{"id": "1"}}, solr.ChildDocuments(doc, ""))
+	assert.Equal(t, []solr.M{{"id": "2"}}, solr.ChildDocuments(doc, "manual"))
+	assert.Equal(t, []solr.M{{"id": "3"}}, solr.ChildDocuments(doc, "parts"))
+	assert.Nil(t, solr.ChildDocuments(doc, "missing"))
+}
+
+func TestChildTransformer(t *testing.T) {
+	got := solr.NewChildTransformer().BuildTransformer()
+	assert.Equal(t, "[child]", got)
+
+	got = solr.NewChildTransformer().
+		ParentFilter("doc_type:product").
+		ChildFilter("color_s:RED").
+		Limit(10).
+		Fields("id", "color_s").
+		BuildTransformer()
+	assert.Equal(t, "[child parentFilter=doc_type:product childFilter=color_s:RED limit=10 fl=id,color_s]", got)
+
+	got = solr.NewChildTransformer().
+		ParentFilter("doc_type:product").
+		ChildFilter("type:comment AND author:bob").
+		BuildTransformer()
+	assert.Equal(t, "[child parentFilter=doc_type:product childFilter='type:comment AND author:bob']", got)
+
+	query := solr.NewQuery("name_s:Stapler").
+		Fields("*", solr.NewChildTransformer().Limit(-1).BuildTransformer()).
+		BuildQuery()
+	assert.Equal(t, []string{"*", "[child limit=-1]"}, query["fields"])
+}
@@ -18,12 +18,15 @@ type Query struct {
 	// supported params in json request api
 	sort    string
 	offset  int      // start
-	limit   int      // rows
+	limit   *int     // rows
 	filters []string // fq
 	fields  []string // fl
 
 	// query is the main query
 	query string
+	// dsl is the main query in JSON query DSL, it takes precedence over query
+	// Refer to https://solr.apache.org/guide/8_8/json-query-dsl.html
+	dsl M
 
 	// facets
 	// Refer to https://lucene.apache.org/solr/guide/8_7/json-facet-api.html
@@ -32,6 +35,24 @@ type Query struct {
 	// additional queries
 	// https://lucene.apache.org/solr/guide/8_7/json-query-dsl.html#additional-queries
 	queries M
+
+	// highlighting
+	// Refer to https://solr.apache.org/guide/8_8/highlighting.html
+	highlight *Highlight
+
+	// spellcheck
+	// Refer to https://solr.apache.org/guide/8_8/spell-checking.html
+	spellcheck *Spellcheck
+
+	// result grouping and collapse/expand
+	// Refer to https://solr.apache.org/guide/8_8/result-grouping.html
+	// and https://solr.apache.org/guide/8_8/collapse-and-expand-results.html
+	group  *Group
+	expand *Expand
+
+	// re-rank query
+	// Refer to https://solr.apache.org/guide/8_8/query-re-ranking.html
+	rerank string // rq
 }
 
 // NewQuery accepts the main query built from the various
@@ -40,9 +61,18 @@ func NewQuery(query string) *Query {
 	return &Query{query: query}
 }
 
+// NewDSLQuery accepts the main query in JSON query DSL
+// e.g. built from the BoolQuery and returns the Query object.
+func NewDSLQuery(dsl M) *Query {
+	return &Query{dsl: dsl}
+}
+
 // BuildQuery builds the query
 func (q *Query) BuildQuery() M {
 	qm := M{"query": q.query}
+	if q.dsl != nil {
+		qm["query"] = q.dsl
+	}
 
 	if q.queries != nil {
 		qm["queries"] = q.queries
@@ -56,8 +86,8 @@ func (q *Query) BuildQuery() M {
 		qm["offset"] = q.offset
 	}
 
-	if q.limit != 0 {
-		qm["limit"] = q.limit
+	if q.limit != nil {
+		qm["limit"] = *q.limit
 	}
 
 	if len(q.filters) > 0 {
@@ -77,9 +107,50 @@ func (q *Query) BuildQuery() M {
 		qm["facet"] = facets
 	}
 
+	params := q.buildParams()
+	if len(params) > 0 {
+		qm["params"] = params
+	}
+
 	return qm
 }
 
+// buildParams builds the request params which are not
+// directly supported by the JSON request API
+func (q *Query) buildParams() M {
+	params := M{}
+
+	if q.highlight != nil {
+		for k, v := range q.highlight.BuildParams() {
+			params[k] = v
+		}
+	}
+
+	if q.spellcheck != nil {
+		for k, v := range q.spellcheck.BuildParams() {
+			params[k] = v
+		}
+	}
+
+	if q.group != nil {
+		for k, v := range q.group.BuildParams() {
+			params[k] = v
+		}
+	}
+
+	if q.expand != nil {
+		for k, v := range q.expand.BuildParams() {
+			params[k] = v
+		}
+	}
+
+	if q.rerank != "" {
+		params["rq"] = q.rerank
+	}
+
+	return params
+}
+
 // Sort sets the sort param
 func (q *Query) Sort(sort string) *Query {
 	q.sort = sort
@@ -92,9 +163,9 @@ func (q *Query) Offset(offset int) *Query {
 	return q
 }
 
-// Limit sets the limit param
+// Limit sets the limit param, a limit of zero only returns the number of matching documents
 func (q *Query) Limit(limit int) *Query {
-	q.limit = limit
+	q.limit = &limit
 	return q
 }
 
@@ -121,3 +192,42 @@ func (q *Query) Queries(queries M) *Query {
 	q.queries = queries
 	return q
 }
+
+// Highlight sets the highlighting params
+func (q *Query) Highlight(highlight *Highlight) *Query {
+	q.highlight = highlight
+	return q
+}
+
+// Spellcheck sets the spellcheck params
+func (q *Query) Spellcheck(spellcheck *Spellcheck) *Query {
+	q.spellcheck = spellcheck
+	return q
+}
+
+// Group sets the result grouping params
+func (q *Query) Group(group *Group) *Query {
+	q.group = group
+	return q
+}
+
+// Expand sets the expand params, used together with a collapse filter
+func (q *Query) Expand(expand *Expand) *Query {
+	q.expand = expand
+	return q
+}
+
+// ReRank sets the re-rank query (rq) e.g. built
+// from the ReRankQueryParser or the LTRQueryParser
+func (q *Query) ReRank(rq string) *Query {
+	q.rerank = rq
+	return q
+}
+
+// withQuery returns a copy of the query with the main query replaced
+func (q *Query) withQuery(query string) *Query {
+	nq := *q
+	nq.query = query
+	nq.dsl = nil
+	return &nq
+}
@@ -2,6 +2,7 @@ package solr
 
 import (
 	"fmt"
+	"sort"
 	"strings"
 )
 
@@ -48,7 +49,7 @@ func (qp *StandardQueryParser) BuildParser() string {
 	}
 
 	if qp.tag != "" {
-		kv = append(kv, fmt.Sprintf("tag=%s", qp.tag))
+		kv = append(kv, fmt.Sprintf("tag=%s", quoteLocalParam(qp.tag)))
 	}
 
 	if qp.q != "" {
@@ -88,8 +89,11 @@ func (qp *StandardQueryParser) Tag(tag string) *StandardQueryParser {
 	return qp
 }
 
-// DisMaxQueryParser is a dismax query parser
+// DisMaxQueryParser is a dismax or edismax query parser
 type DisMaxQueryParser struct {
+	// parser is either dismax or edismax
+	parser string
+
 	// dismax q parser params
 	// reference: https://lucene.apache.org/solr/guide/8_7/the-dismax-q-parser.html
 	q   string // query
@@ -108,12 +112,20 @@ var _ QueryParser = (*DisMaxQueryParser)(nil)
 
 // NewDisMaxQueryParser returns a new DisMaxQueryParser
 func NewDisMaxQueryParser() *DisMaxQueryParser {
-	return &DisMaxQueryParser{}
+	return &DisMaxQueryParser{parser: "dismax"}
+}
+
+// NewExtendedDisMaxQueryParser returns a new DisMaxQueryParser
+// for the edismax query parser, which supports the dismax params
+//
+// Refer to https://solr.apache.org/guide/8_8/the-extended-dismax-query-parser.html
+func NewExtendedDisMaxQueryParser() *DisMaxQueryParser {
+	return &DisMaxQueryParser{parser: "edismax"}
 }
 
 // BuildParser builds the query parser
 func (qp *DisMaxQueryParser) BuildParser() string {
-	kv := []string{"dismax"}
+	kv := []string{qp.parser}
 	if qp.alt != "" {
 		kv = append(kv, fmt.Sprintf("q.alt=%s", qp.alt))
 	}
@@ -243,7 +255,7 @@ func (qp *ParentQueryParser) BuildParser() string {
 	}
 
 	if qp.tag != "" {
-		kv = append(kv, fmt.Sprintf("tag=%s", qp.tag))
+		kv = append(kv, fmt.Sprintf("tag=%s", quoteLocalParam(qp.tag)))
 	}
 
 	if qp.filters != "" {
@@ -413,3 +425,593 @@ func (qp *FiltersQueryParser) Query(query string) *FiltersQueryParser {
 	qp.q = query
 	return qp
 }
+
+// CollapseQueryParser is a collapsing query parser, used as
+// a filter to collapse the results to one document per group
+//
+// Refer to https://solr.apache.org/guide/8_8/collapse-and-expand-results.html#collapsing-query-parser
+type CollapseQueryParser struct {
+	field      string
+	min        string
+	max        string
+	sort       string
+	nullPolicy string
+	hint       string
+	size       int
+}
+
+var _ QueryParser = (*CollapseQueryParser)(nil)
+
+// NewCollapseQueryParser returns a new CollapseQueryParser
+func NewCollapseQueryParser() *CollapseQueryParser {
+	return &CollapseQueryParser{}
+}
+
+// BuildParser builds the query parser
+func (qp *CollapseQueryParser) BuildParser() string {
+	kv := []string{"collapse"}
+
+	if qp.field != "" {
+		kv = append(kv, fmt.Sprintf("field=%s", qp.field))
+	}
+
+	if qp.min != "" {
+		kv = append(kv, fmt.Sprintf("min=%s", qp.min))
+	}
+
+	if qp.max != "" {
+		kv = append(kv, fmt.Sprintf("max=%s", qp.max))
+	}
+
+	if qp.sort != "" {
+		kv = append(kv, fmt.Sprintf("sort=%s", qp.sort))
+	}
+
+	if qp.nullPolicy != "" {
+		kv = append(kv, fmt.Sprintf("nullPolicy=%s", qp.nullPolicy))
+	}
+
+	if qp.hint != "" {
+		kv = append(kv, fmt.Sprintf("hint=%s", qp.hint))
+	}
+
+	if qp.size > 0 {
+		kv = append(kv, fmt.Sprintf("size=%d", qp.size))
+	}
+
+	return fmt.Sprintf("{!%s}", strings.Join(kv, " "))
+}
+
+// Field sets the field to collapse on
+func (qp *CollapseQueryParser) Field(field string) *CollapseQueryParser {
+	qp.field = field
+	return qp
+}
+
+// Min selects the group head with the minimum value of the field or function
+func (qp *CollapseQueryParser) Min(min string) *CollapseQueryParser {
+	qp.min = min
+	return qp
+}
+
+// Max selects the group head with the maximum value of the field or function
+func (qp *CollapseQueryParser) Max(max string) *CollapseQueryParser {
+	qp.max = max
+	return qp
+}
+
+// Sort selects the group head using the sort e.g. 'price asc'
+func (qp *CollapseQueryParser) Sort(sort string) *CollapseQueryParser {
+	qp.sort = sort
+	return qp
+}
+
+// NullPolicy sets how documents without a value
+// are handled (ignore, expand or collapse)
+func (qp *CollapseQueryParser) NullPolicy(nullPolicy string) *CollapseQueryParser {
+	qp.nullPolicy = nullPolicy
+	return qp
+}
+
+// Hint sets the hint param, e.g. top_fc
+func (qp *CollapseQueryParser) Hint(hint string) *CollapseQueryParser {
+	qp.hint = hint
+	return qp
+}
+
+// Size sets the initial size of the collapse data structures
+func (qp *CollapseQueryParser) Size(size int) *CollapseQueryParser {
+	qp.size = size
+	return qp
+}
+
+// MLTQueryParser is a more like this query parser, which
+// finds documents similar to the document with the given id
+//
+// Refer to https://solr.apache.org/guide/8_8/other-parsers.html#more-like-this-query-parser
+type MLTQueryParser struct {
+	qf    []string
+	minTF int
+	minDF int
+	maxDF int
+	maxQT int
+	boost bool
+	id    string
+}
+
+var _ QueryParser = (*MLTQueryParser)(nil)
+
+// NewMLTQueryParser returns a new MLTQueryParser
+func NewMLTQueryParser() *MLTQueryParser {
+	return &MLTQueryParser{}
+}
+
+// BuildParser builds the query parser
+func (qp *MLTQueryParser) BuildParser() string {
+	kv := []string{"mlt"}
+
+	if len(qp.qf) > 0 {
+		kv = append(kv, fmt.Sprintf("qf=%s", strings.Join(qp.qf, ",")))
+	}
+
+	if qp.minTF > 0 {
+		kv = append(kv, fmt.Sprintf("mintf=%d", qp.minTF))
+	}
+
+	if qp.minDF > 0 {
+		kv = append(kv, fmt.Sprintf("mindf=%d", qp.minDF))
+	}
+
+	if qp.maxDF > 0 {
+		kv = append(kv, fmt.Sprintf("maxdf=%d", qp.maxDF))
+	}
+
+	if qp.maxQT > 0 {
+		kv = append(kv, fmt.Sprintf("maxqt=%d", qp.maxQT))
+	}
+
+	if qp.boost {
+		kv = append(kv, "boost=true")
+	}
+
+	return fmt.Sprintf("{!%s}%s", strings.Join(kv, " "), qp.id)
+}
+
+// ID sets the unique key of the document to find similar documents for
+func (qp *MLTQueryParser) ID(id string) *MLTQueryParser {
+	qp.id = id
+	return qp
+}
+
+// Qf sets the fields to use for similarity
+func (qp *MLTQueryParser) Qf(qf ...string) *MLTQueryParser {
+	qp.qf = qf
+	return qp
+}
+
+// MinTF sets the minimum term frequency, below which terms are ignored
+func (qp *MLTQueryParser) MinTF(minTF int) *MLTQueryParser {
+	qp.minTF = minTF
+	return qp
+}
+
+// MinDF sets the minimum document frequency, below which terms are ignored
+func (qp *MLTQueryParser) MinDF(minDF int) *MLTQueryParser {
+	qp.minDF = minDF
+	return qp
+}
+
+// MaxDF sets the maximum document frequency, above which terms are ignored
+func (qp *MLTQueryParser) MaxDF(maxDF int) *MLTQueryParser {
+	qp.maxDF = maxDF
+	return qp
+}
+
+// MaxQT sets the maximum number of query terms
+func (qp *MLTQueryParser) MaxQT(maxQT int) *MLTQueryParser {
+	qp.maxQT = maxQT
+	return qp
+}
+
+// Boost if true, boosts the query terms by their relevance
+func (qp *MLTQueryParser) Boost(boost bool) *MLTQueryParser {
+	qp.boost = boost
+	return qp
+}
+
+// SpatialQueryParser is a geofilt or bbox spatial query parser
+//
+// Refer to https://solr.apache.org/guide/8_8/spatial-search.html#geofilt
+type SpatialQueryParser struct {
+	name   string
+	sfield string
+	pt     *LatLon
+	d      float64
+	score  string
+	filter *bool
+}
+
+var _ QueryParser = (*SpatialQueryParser)(nil)
+
+// NewGeofiltQueryParser returns a new geofilt SpatialQueryParser, which
+// matches the documents within the distance (radius) of the point
+func NewGeofiltQueryParser() *SpatialQueryParser {
+	return &SpatialQueryParser{name: "geofilt"}
+}
+
+// NewBBoxQueryParser returns a new bbox SpatialQueryParser, which matches
+// the documents within the bounding box of the circle around the point
+func NewBBoxQueryParser() *SpatialQueryParser {
+	return &SpatialQueryParser{name: "bbox"}
+}
+
+// BuildParser builds the query parser
+func (qp *SpatialQueryParser) BuildParser() string {
+	kv := []string{qp.name}
+
+	if qp.sfield != "" {
+		kv = append(kv, fmt.Sprintf("sfield=%s", qp.sfield))
+	}
+
+	if qp.pt != nil {
+		kv = append(kv, fmt.Sprintf("pt=%s", qp.pt))
+	}
+
+	if qp.d > 0 {
+		kv = append(kv, fmt.Sprintf("d=%s", formatFloat(qp.d)))
+	}
+
+	if qp.score != "" {
+		kv = append(kv, fmt.Sprintf("score=%s", qp.score))
+	}
+
+	if qp.filter != nil {
+		kv = append(kv, fmt.Sprintf("filter=%t", *qp.filter))
+	}
+
+	return fmt.Sprintf("{!%s}", strings.Join(kv, " "))
+}
+
+// Sfield sets the spatial field
+func (qp *SpatialQueryParser) Sfield(sfield string) *SpatialQueryParser {
+	qp.sfield = sfield
+	return qp
+}
+
+// Pt sets the center point
+func (qp *SpatialQueryParser) Pt(pt LatLon) *SpatialQueryParser {
+	qp.pt = &pt
+	return qp
+}
+
+// D sets the radial distance, usually in kilometers
+func (qp *SpatialQueryParser) D(d float64) *SpatialQueryParser {
+	qp.d = d
+	return qp
+}
+
+// Score sets the score param (none, kilometers, miles, degrees, distance or recipDistance),
+// which makes the distance the score of the query
+func (qp *SpatialQueryParser) Score(score string) *SpatialQueryParser {
+	qp.score = score
+	return qp
+}
+
+// Filter sets the filter param, set to false to use the query for scoring only
+func (qp *SpatialQueryParser) Filter(filter bool) *SpatialQueryParser {
+	qp.filter = &filter
+	return qp
+}
+
+// FrangeQueryParser is a function range query parser, which
+// matches the documents whose function value is within the range
+//
+// Refer to https://solr.apache.org/guide/8_8/other-parsers.html#function-range-query-parser
+type FrangeQueryParser struct {
+	f    *Function
+	l    string // lower bound
+	u    string // upper bound
+	incl *bool  // include lower bound
+	incu *bool  // include upper bound
+	tag  string
+}
+
+var _ QueryParser = (*FrangeQueryParser)(nil)
+
+// NewFrangeQueryParser returns a new FrangeQueryParser. The function
+// is not validated, use Function.Build to check its arguments.
+func NewFrangeQueryParser(f *Function) *FrangeQueryParser {
+	return &FrangeQueryParser{f: f}
+}
+
+// BuildParser builds the query parser
+func (qp *FrangeQueryParser) BuildParser() string {
+	kv := []string{"frange"}
+
+	if qp.l != "" {
+		kv = append(kv, fmt.Sprintf("l=%s", quoteLocalParam(qp.l)))
+	}
+
+	if qp.u != "" {
+		kv = append(kv, fmt.Sprintf("u=%s", quoteLocalParam(qp.u)))
+	}
+
+	if qp.incl != nil {
+		kv = append(kv, fmt.Sprintf("incl=%t", *qp.incl))
+	}
+
+	if qp.incu != nil {
+		kv = append(kv, fmt.Sprintf("incu=%t", *qp.incu))
+	}
+
+	if qp.tag != "" {
+		kv = append(kv, fmt.Sprintf("tag=%s", quoteLocalParam(qp.tag)))
+	}
+
+	return fmt.Sprintf("{!%s}%s", strings.Join(kv, " "), qp.f)
+}
+
+// L sets the lower bound
+func (qp *FrangeQueryParser) L(l float64) *FrangeQueryParser {
+	qp.l = formatFloat(l)
+	return qp
+}
+
+// U sets the upper bound
+func (qp *FrangeQueryParser) U(u float64) *FrangeQueryParser {
+	qp.u = formatFloat(u)
+	return qp
+}
+
+// Incl sets whether to include the lower bound (default true)
+func (qp *FrangeQueryParser) Incl(incl bool) *FrangeQueryParser {
+	qp.incl = &incl
+	return qp
+}
+
+// Incu sets whether to include the upper bound (default true)
+func (qp *FrangeQueryParser) Incu(incu bool) *FrangeQueryParser {
+	qp.incu = &incu
+	return qp
+}
+
+// Tag sets the tag
+func (qp *FrangeQueryParser) Tag(tag string) *FrangeQueryParser {
+	qp.tag = tag
+	return qp
+}
+
+// BoostQueryParser is a boost query parser, which multiplies
+// the score of the query by the value of the function
+//
+// Refer to https://solr.apache.org/guide/8_8/other-parsers.html#boost-query-parser
+type BoostQueryParser struct {
+	b *Function
+	q string
+}
+
+var _ QueryParser = (*BoostQueryParser)(nil)
+
+// NewBoostQueryParser returns a new BoostQueryParser. The boost
+// function is not validated, use Function.Build to check its arguments.
+func NewBoostQueryParser(b *Function) *BoostQueryParser {
+	return &BoostQueryParser{b: b}
+}
+
+// BuildParser builds the query parser
+func (qp *BoostQueryParser) BuildParser() string {
+	kv := []string{"boost", fmt.Sprintf("b=%s", quoteLocalParam(qp.b.String()))}
+
+	if qp.q != "" {
+		kv = append(kv, fmt.Sprintf("v=%s", quoteLocalParam(qp.q)))
+	}
+
+	return fmt.Sprintf("{!%s}", strings.Join(kv, " "))
+}
+
+// Query sets the query to boost
+func (qp *BoostQueryParser) Query(query string) *BoostQueryParser {
+	qp.q = query
+	return qp
+}
+
+// ReRankQueryParser is a re-rank query parser, which re-ranks the
+// top documents of the main query using a more expensive query
+//
+// Refer to https://solr.apache.org/guide/8_8/query-re-ranking.html#rerank-query-parser
+type ReRankQueryParser struct {
+	reRankQuery  string
+	reRankDocs   int
+	reRankWeight float64
+}
+
+var _ QueryParser = (*ReRankQueryParser)(nil)
+
+// NewReRankQueryParser returns a new ReRankQueryParser
+func NewReRankQueryParser() *ReRankQueryParser {
+	return &ReRankQueryParser{}
+}
+
+// BuildParser builds the query parser
+func (qp *ReRankQueryParser) BuildParser() string {
+	kv := []string{"rerank"}
+
+	if qp.reRankQuery != "" {
+		kv = append(kv, fmt.Sprintf("reRankQuery=%s", quoteLocalParam(qp.reRankQuery)))
+	}
+
+	if qp.reRankDocs > 0 {
+		kv = append(kv, fmt.Sprintf("reRankDocs=%d", qp.reRankDocs))
+	}
+
+	if qp.reRankWeight != 0 {
+		kv = append(kv, fmt.Sprintf("reRankWeight=%s", formatFloat(qp.reRankWeight)))
+	}
+
+	return fmt.Sprintf("{!%s}", strings.Join(kv, " "))
+}
+
+// ReRankQuery sets the query used to re-rank the documents
+// e.g. $rqq to refer to the rqq param
+func (qp *ReRankQueryParser) ReRankQuery(reRankQuery string) *ReRankQueryParser {
+	qp.reRankQuery = reRankQuery
+	return qp
+}
+
+// ReRankDocs sets the number of top documents to re-rank
+func (qp *ReRankQueryParser) ReRankDocs(reRankDocs int) *ReRankQueryParser {
+	qp.reRankDocs = reRankDocs
+	return qp
+}
+
+// ReRankWeight sets the multiplier of the re-rank query score
+func (qp *ReRankQueryParser) ReRankWeight(reRankWeight float64) *ReRankQueryParser {
+	qp.reRankWeight = reRankWeight
+	return qp
+}
+
+// LTRQueryParser is a learning to rank query parser, which
+// re-ranks the top documents using a model from the model store
+//
+// Refer to https://solr.apache.org/guide/8_8/learning-to-rank.html#running-a-rerank-query
+type LTRQueryParser struct {
+	model      string
+	reRankDocs int
+	efi        map[string]string
+}
+
+var _ QueryParser = (*LTRQueryParser)(nil)
+
+// NewLTRQueryParser returns a new LTRQueryParser
+func NewLTRQueryParser(model string) *LTRQueryParser {
+	return &LTRQueryParser{model: model, efi: map[string]string{}}
+}
+
+// BuildParser builds the query parser
+func (qp *LTRQueryParser) BuildParser() string {
+	kv := []string{"ltr", fmt.Sprintf("model=%s", quoteLocalParam(qp.model))}
+
+	if qp.reRankDocs > 0 {
+		kv = append(kv, fmt.Sprintf("reRankDocs=%d", qp.reRankDocs))
+	}
+
+	return fmt.Sprintf("{!%s}", strings.Join(append(kv, buildEfi(qp.efi)...), " "))
+}
+
+// ReRankDocs sets the number of top documents to re-rank
+func (qp *LTRQueryParser) ReRankDocs(reRankDocs int) *LTRQueryParser {
+	qp.reRankDocs = reRankDocs
+	return qp
+}
+
+// Efi sets an external feature information param (efi.key=value)
+func (qp *LTRQueryParser) Efi(key, value string) *LTRQueryParser {
+	qp.efi[key] = value
+	return qp
+}
+
+// buildEfi builds the external feature information params sorted by key,
+// values with whitespace or quotes are enclosed in single quotes
+func buildEfi(efi map[string]string) []string {
+	keys := make([]string, 0, len(efi))
+	for k := range efi {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	kv := make([]string, 0, len(keys))
+	for _, k := range keys {
+		kv = append(kv, fmt.Sprintf("efi.%s=%s", k, quoteLocalParam(efi[k])))
+	}
+
+	return kv
+}
+
+// quoteLocalParam single-quotes the local param value if it contains
+// whitespace, quotes or a closing brace, escaping backslashes and quotes
+func quoteLocalParam(v string) string {
+	if !strings.ContainsAny(v, " \t\n'\"}") {
+		return v
+	}
+
+	return "'" + strings.NewReplacer(`\`, `\\`, `'`, `\'`).Replace(v) + "'"
+}
+
+// KNNQueryParser is a k-nearest neighbors query parser, which
+// matches the topK documents closest to the vector
+//
+// Refer to https://solr.apache.org/guide/solr/latest/query-guide/dense-vector-search.html#knn-query-parser
+type KNNQueryParser struct {
+	f          string
+	vector     Vector
+	topK       int
+	preFilters []string
+	tag        string
+}
+
+var _ QueryParser = (*KNNQueryParser)(nil)
+
+// NewKNNQueryParser returns a new KNNQueryParser for the
+// vector field (DenseVectorField) and the query vector
+func NewKNNQueryParser(field string, vector Vector) *KNNQueryParser {
+	return &KNNQueryParser{f: field, vector: vector}
+}
+
+// BuildParser builds the query parser
+func (qp *KNNQueryParser) BuildParser() string {
+	kv := []string{"knn", fmt.Sprintf("f=%s", qp.f)}
+
+	if qp.topK > 0 {
+		kv = append(kv, fmt.Sprintf("topK=%d", qp.topK))
+	}
+
+	for _, preFilter := range qp.preFilters {
+		kv = append(kv, fmt.Sprintf("preFilter=%s", quoteLocalParam(preFilter)))
+	}
+
+	if qp.tag != "" {
+		kv = append(kv, fmt.Sprintf("tag=%s", quoteLocalParam(qp.tag)))
+	}
+
+	return fmt.Sprintf("{!%s}%s", strings.Join(kv, " "), qp.vector)
+}
+
+// BuildDSL builds the query parser in JSON query DSL,
+// which can be used as a clause of the BoolQuery
+func (qp *KNNQueryParser) BuildDSL() M {
+	m := M{"f": qp.f, "query": qp.vector.String()}
+
+	if qp.topK > 0 {
+		m["topK"] = qp.topK
+	}
+
+	if len(qp.preFilters) > 0 {
+		m["preFilter"] = qp.preFilters
+	}
+
+	if qp.tag != "" {
+		m["tag"] = qp.tag
+	}
+
+	return M{"knn": m}
+}
+
+// TopK sets the number of nearest neighbors to return (default 10)
+func (qp *KNNQueryParser) TopK(topK int) *KNNQueryParser {
+	qp.topK = topK
+	return qp
+}
+
+// PreFilters sets the filters applied before the nearest neighbors are searched.
+// The filters (fq) of the Query are used as pre-filters only when the knn query
+// is the main query, so pre-filters must be set explicitly in hybrid queries.
+func (qp *KNNQueryParser) PreFilters(preFilters ...string) *KNNQueryParser {
+	qp.preFilters = preFilters
+	return qp
+}
+
+// Tag sets the tag
+func (qp *KNNQueryParser) Tag(tag string) *KNNQueryParser {
+	qp.tag = tag
+	return qp
+}
@@ -54,6 +54,13 @@ func TestQueryParsers(t *testing.T) {
 		a.Equal(expect, got)
 	})
 
+	t.Run("edismax query parser", func(t *testing.T) {
+		a := assert.New(t)
+		got := solr.NewExtendedDisMaxQueryParser().
+			Query("'solr rocks'").Qf("'name^2 features'").Mm("2").BuildParser()
+		a.Equal("{!edismax qf='name^2 features' mm=2 v='solr rocks'}", got)
+	})
+
 	t.Run("parent query parser", func(t *testing.T) {
 		a := assert.New(t)
 		got := solr.NewParentQueryParser().
@@ -91,4 +98,127 @@ func TestQueryParsers(t *testing.T) {
 		a.Equal(expect, got)
 	})
 
+	t.Run("collapse query parser", func(t *testing.T) {
+		a := assert.New(t)
+		got := solr.NewCollapseQueryParser().
+			Field("group_id").
+			BuildParser()
+		a.Equal("{!collapse field=group_id}", got)
+
+		got = solr.NewCollapseQueryParser().
+			Field("group_id").
+			Min("price").
+			Max("popularity").
+			Sort("'price asc'").
+			NullPolicy("expand").
+			Hint("top_fc").
+			Size(5000).
+			BuildParser()
+		expect := `{!collapse field=group_id min=price max=popularity sort='price asc' nullPolicy=expand hint=top_fc size=5000}`
+		a.Equal(expect, got)
+	})
+
+	t.Run("mlt query parser", func(t *testing.T) {
+		a := assert.New(t)
+		got := solr.NewMLTQueryParser().ID("SP2514N").BuildParser()
+		a.Equal("{!mlt}SP2514N", got)
+
+		got = solr.NewMLTQueryParser().
+			ID("SP2514N").
+			Qf("name", "features").
+			MinTF(1).
+			MinDF(2).
+			MaxDF(100).
+			MaxQT(25).
+			Boost(true).
+			BuildParser()
+		expect := `{!mlt qf=name,features mintf=1 mindf=2 maxdf=100 maxqt=25 boost=true}SP2514N`
+		a.Equal(expect, got)
+	})
+
+	t.Run("spatial query parsers", func(t *testing.T) {
+		a := assert.New(t)
+		pt := solr.LatLon{Lat: 45.15, Lon: -93.85}
+		got := solr.NewGeofiltQueryParser().Sfield("store").Pt(pt).D(5).BuildParser()
+		a.Equal("{!geofilt sfield=store pt=45.15,-93.85 d=5}", got)
+
+		got = solr.NewBBoxQueryParser().
+			Sfield("store").
+			Pt(pt).
+			D(2.5).
+			Score("kilometers").
+			Filter(false).
+			BuildParser()
+		a.Equal("{!bbox sfield=store pt=45.15,-93.85 d=2.5 score=kilometers filter=false}", got)
+	})
+
+	t.Run("frange query parser", func(t *testing.T) {
+		a := assert.New(t)
+		got := solr.NewFrangeQueryParser(solr.FuncSum(solr.FuncField("x"), solr.FuncField("y"))).BuildParser()
+		a.Equal("{!frange}sum(x,y)", got)
+
+		got = solr.NewFrangeQueryParser(solr.FuncSum(solr.FuncField("x"), solr.FuncField("y"))).
+			L(0).U(2.2).Incl(false).Incu(true).Tag("xy").BuildParser()
+		a.Equal("{!frange l=0 u=2.2 incl=false incu=true tag=xy}sum(x,y)", got)
+
+		got = solr.NewFrangeQueryParser(solr.FuncField("price")).Tag("my tag").BuildParser()
+		a.Equal("{!frange tag='my tag'}price", got)
+	})
+
+	t.Run("boost query parser", func(t *testing.T) {
+		a := assert.New(t)
+		b := solr.FuncRecip(solr.FuncMs(solr.FuncRaw("NOW"), solr.FuncField("mydate")), 3.16e-11, 1, 1)
+		got := solr.NewBoostQueryParser(b).Query("foo").BuildParser()
+		a.Equal("{!boost b=recip(ms(NOW,mydate),0.0000000000316,1,1) v=foo}", got)
+
+		b = solr.NewFunction("termfreq", solr.FuncField("text"), solr.FuncStr("hello world"))
+		got = solr.NewBoostQueryParser(b).Query("ipod nano").BuildParser()
+		a.Equal(`{!boost b='termfreq(text,"hello world")' v='ipod nano'}`, got)
+	})
+
+	t.Run("rerank query parser", func(t *testing.T) {
+		a := assert.New(t)
+		got := solr.NewReRankQueryParser().
+			ReRankQuery("$rqq").
+			ReRankDocs(1000).
+			ReRankWeight(3).
+			BuildParser()
+		a.Equal("{!rerank reRankQuery=$rqq reRankDocs=1000 reRankWeight=3}", got)
+
+		got = solr.NewReRankQueryParser().
+			ReRankQuery("title:foo bar's").
+			BuildParser()
+		a.Equal(`{!rerank reRankQuery='title:foo bar\'s'}`, got)
+	})
+
+	t.Run("ltr query parser", func(t *testing.T) {
+		a := assert.New(t)
+		got := solr.NewLTRQueryParser("myModel").BuildParser()
+		a.Equal("{!ltr model=myModel}", got)
+
+		got = solr.NewLTRQueryParser("myModel").
+			ReRankDocs(100).
+			Efi("text", "it's a test").
+			Efi("user", "u1").
+			BuildParser()
+		a.Equal(`{!ltr model=myModel reRankDocs=100 efi.text='it\'s a test' efi.user=u1}`, got)
+	})
+
+	t.Run("knn query parser", func(t *testing.T) {
+		a := assert.New(t)
+		got := solr.NewKNNQueryParser("vector", solr.Vector{1, 2, 3}).BuildParser()
+		a.Equal("{!knn f=vector}[1,2,3]", got)
+
+		got = solr.NewKNNQueryParser("vector", solr.Vector{0.5, -1}).
+			TopK(10).
+			PreFilters("inStock:true", "cat:electronics").
+			Tag("knn").
+			BuildParser()
+		a.Equal("{!knn f=vector topK=10 preFilter=inStock:true preFilter=cat:electronics tag=knn}[0.5,-1]", got)
+
+		got = solr.NewKNNQueryParser("vector", solr.Vector{1}).
+			PreFilters("inStock:true AND cat:x").
+			BuildParser()
+		a.Equal("{!knn f=vector preFilter='inStock:true AND cat:x'}[1]", got)
+	})
 }
@@ -0,0 +1,140 @@
+package solr
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+)
+
+// decodeQueryStream decodes the query response into resp, calling fn
+// for each document of the main response instead of collecting them,
+// so that only one document is held in memory at a time
+func decodeQueryStream(r io.Reader, resp *QueryResponse, fn func(doc M) error) error {
+	dec := json.NewDecoder(r)
+	err := expectDelim(dec, '{')
+	if err != nil {
+		return err
+	}
+
+	rest := map[string]json.RawMessage{}
+	for dec.More() {
+		key, err := readKey(dec)
+		if err != nil {
+			return err
+		}
+
+		if key == "response" {
+			err = decodeQueryStreamBody(dec, &resp.Response, fn)
+			if err != nil {
+				return wrapErr(err, "decode response")
+			}
+			continue
+		}
+
+		var raw json.RawMessage
+		err = dec.Decode(&raw)
+		if err != nil {
+			return wrapErr(err, "decode "+key)
+		}
+		rest[key] = raw
+	}
+
+	// decode the other sections e.g. responseHeader and facets
+	return decodeRawObject(rest, resp)
+}
+
+// decodeQueryStreamBody decodes the response body, calling fn for each document
+func decodeQueryStreamBody(dec *json.Decoder, body *QueryResponseBody, fn func(doc M) error) error {
+	err := expectDelim(dec, '{')
+	if err != nil {
+		return err
+	}
+
+	rest := map[string]json.RawMessage{}
+	for dec.More() {
+		key, err := readKey(dec)
+		if err != nil {
+			return err
+		}
+
+		if key != "docs" {
+			var raw json.RawMessage
+			err = dec.Decode(&raw)
+			if err != nil {
+				return wrapErr(err, "decode "+key)
+			}
+			rest[key] = raw
+			continue
+		}
+
+		err = expectDelim(dec, '[')
+		if err != nil {
+			return err
+		}
+
+		for dec.More() {
+			var doc M
+			err = dec.Decode(&doc)
+			if err != nil {
+				return wrapErr(err, "decode document")
+			}
+
+			err = fn(doc)
+			if err != nil {
+				return err
+			}
+		}
+
+		err = expectDelim(dec, ']')
+		if err != nil {
+			return err
+		}
+	}
+
+	err = expectDelim(dec, '}')
+	if err != nil {
+		return err
+	}
+
+	// decode numFound, start and maxScore
+	return decodeRawObject(rest, body)
+}
+
+// decodeRawObject decodes the raw values by key into v
+func decodeRawObject(obj map[string]json.RawMessage, v interface{}) error {
+	b, err := json.Marshal(obj)
+	if err != nil {
+		return err
+	}
+
+	return json.Unmarshal(b, v)
+}
+
+// readKey reads the next key of an object
+func readKey(dec *json.Decoder) (string, error) {
+	tok, err := dec.Token()
+	if err != nil {
+		return "", err
+	}
+
+	key, ok := tok.(string)
+	if !ok {
+		return "", fmt.Errorf("expecting a key but got %v", tok)
+	}
+
+	return key, nil
+}
+
+// expectDelim reads the next token and checks that it is the delimiter
+func expectDelim(dec *json.Decoder, delim json.Delim) error {
+	tok, err := dec.Token()
+	if err != nil {
+		return err
+	}
+
+	if tok != delim {
+		return fmt.Errorf("expecting %v but got %v", delim, tok)
+	}
+
+	return nil
+}
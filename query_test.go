@@ -65,4 +65,7 @@ func TestQuery(t *testing.T) {
 	}
 
 	a.Equal(expect, got)
+
+	got = solr.NewQuery("*:*").Limit(0).BuildQuery()
+	a.Equal(solr.M{"query": "*:*", "limit": 0}, got)
 }
@@ -0,0 +1,74 @@
+package solr
+
+import (
+	"net/url"
+	"strings"
+)
+
+// RealTimeGetParams is the real-time get API param builder
+type RealTimeGetParams struct {
+	ids     []string
+	fields  []string // fl
+	filters []string // fq
+	route   string   // _route_
+	shards  []string
+}
+
+// NewRealTimeGetParams takes the ids of the documents
+// to fetch and returns a new RealTimeGetParams
+func NewRealTimeGetParams(ids ...string) *RealTimeGetParams {
+	return &RealTimeGetParams{ids: ids}
+}
+
+// Fields sets the fl param
+func (p *RealTimeGetParams) Fields(fields ...string) *RealTimeGetParams {
+	p.fields = fields
+	return p
+}
+
+// Filters sets the fq param
+func (p *RealTimeGetParams) Filters(filters ...string) *RealTimeGetParams {
+	p.filters = filters
+	return p
+}
+
+// Route sets the _route_ param, used to find documents
+// in collections that use the implicit router
+func (p *RealTimeGetParams) Route(route string) *RealTimeGetParams {
+	p.route = route
+	return p
+}
+
+// Shards sets the shards param, used to limit
+// the shards that will be searched for the documents
+func (p *RealTimeGetParams) Shards(shards ...string) *RealTimeGetParams {
+	p.shards = shards
+	return p
+}
+
+// BuildParams builds the parameters
+func (p *RealTimeGetParams) BuildParams() string {
+	vals := &url.Values{}
+
+	for _, id := range p.ids {
+		vals.Add("id", id)
+	}
+
+	if len(p.fields) > 0 {
+		vals.Add("fl", strings.Join(p.fields, ","))
+	}
+
+	for _, fq := range p.filters {
+		vals.Add("fq", fq)
+	}
+
+	if p.route != "" {
+		vals.Add("_route_", p.route)
+	}
+
+	if len(p.shards) > 0 {
+		vals.Add("shards", strings.Join(p.shards, ","))
+	}
+
+	return vals.Encode()
+}
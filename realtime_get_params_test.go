@@ -0,0 +1,21 @@
+package solr_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/stevenferrer/solr-go"
+)
+
+func TestBuildRealTimeGetParams(t *testing.T) {
+	got := solr.NewRealTimeGetParams("mydoc", "otherdoc").
+		Fields("id", "name").
+		Filters("inStock:true", "price:[* TO 100]").
+		Route("shard1").
+		Shards("shard1", "shard2").
+		BuildParams()
+
+	expect := "_route_=shard1&fl=id%2Cname&fq=inStock%3Atrue&fq=price%3A%5B%2A+TO+100%5D&id=mydoc&id=otherdoc&shards=shard1%2Cshard2"
+	assert.Equal(t, expect, got)
+}
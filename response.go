@@ -1,6 +1,13 @@
 package solr
 
-import "time"
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
 
 // BaseResponse is the base response
 type BaseResponse struct {
@@ -20,15 +27,202 @@ type ResponseError struct {
 	Code     int      `json:"code"`
 	Metadata []string `json:"metadata"`
 	Msg      string   `json:"msg"`
+	// Details is the errors of the failed commands
+	// e.g. when modifying the schema
+	Details []CommandError `json:"details,omitempty"`
 }
 
 func (e ResponseError) Error() string {
-	return e.Msg
+	if len(e.Details) == 0 {
+		return e.Msg
+	}
+
+	details := make([]string, 0, len(e.Details))
+	for _, detail := range e.Details {
+		details = append(details, detail.Error())
+	}
+
+	return e.Msg + ": " + strings.Join(details, "; ")
+}
+
+// CommandError is the error of a failed command
+type CommandError struct {
+	// Command is the name of the command e.g. add-field
+	Command string
+	// Body is the body of the command
+	Body json.RawMessage
+	// ErrorMessages is the error messages of the command
+	ErrorMessages []string
+}
+
+func (e CommandError) Error() string {
+	msgs := make([]string, 0, len(e.ErrorMessages))
+	for _, msg := range e.ErrorMessages {
+		msgs = append(msgs, strings.TrimSpace(msg))
+	}
+
+	return e.Command + ": " + strings.Join(msgs, ", ")
+}
+
+// UnmarshalJSON implements json.Unmarshaler
+func (e *CommandError) UnmarshalJSON(b []byte) error {
+	var m map[string]json.RawMessage
+	err := json.Unmarshal(b, &m)
+	if err != nil {
+		return err
+	}
+
+	for k, v := range m {
+		if k == "errorMessages" {
+			err = json.Unmarshal(v, &e.ErrorMessages)
+			if err != nil {
+				return wrapErr(err, "unmarshal error messages")
+			}
+			continue
+		}
+
+		e.Command, e.Body = k, v
+	}
+
+	return nil
+}
+
+// responseError returns the response error, if any
+func (r *BaseResponse) responseError() *ResponseError {
+	if r == nil {
+		return nil
+	}
+
+	return r.Error
+}
+
+// VersionConflictError is returned when an update fails
+// because of a _version_ constraint (HTTP 409)
+type VersionConflictError struct {
+	*ResponseError
+}
+
+// Unwrap returns the underlying response error
+func (e *VersionConflictError) Unwrap() error {
+	return e.ResponseError
+}
+
+// IsVersionConflict returns true if the error is a version conflict error
+func IsVersionConflict(err error) bool {
+	var conflictErr *VersionConflictError
+	return errors.As(err, &conflictErr)
 }
 
 // UpdateResponse is an update response
 type UpdateResponse struct {
 	*BaseResponse
+	// Adds is the versions of the added documents,
+	// only available when versions=true
+	Adds DocVersions `json:"adds,omitempty"`
+	// Deletes is the versions of the deleted documents,
+	// only available when versions=true
+	Deletes DocVersions `json:"deletes,omitempty"`
+}
+
+// DocVersion is the version assigned to a document
+type DocVersion struct {
+	ID      string
+	Version int64
+}
+
+// DocVersions is a list of document versions
+type DocVersions []DocVersion
+
+// UnmarshalJSON implements json.Unmarshaler. Solr returns the
+// versions as a flat list of alternating ids and versions.
+func (dv *DocVersions) UnmarshalJSON(b []byte) error {
+	dec := json.NewDecoder(bytes.NewReader(b))
+	dec.UseNumber()
+
+	var pairs []interface{}
+	err := dec.Decode(&pairs)
+	if err != nil {
+		return err
+	}
+
+	if len(pairs)%2 != 0 {
+		return fmt.Errorf("expecting id and version pairs but got %d items", len(pairs))
+	}
+
+	versions := make(DocVersions, 0, len(pairs)/2)
+	for i := 0; i < len(pairs); i += 2 {
+		version, err := toVersion(pairs[i+1])
+		if err != nil {
+			return wrapErr(err, "parse version")
+		}
+
+		versions = append(versions, DocVersion{
+			ID:      fmt.Sprint(pairs[i]),
+			Version: version,
+		})
+	}
+
+	*dv = versions
+	return nil
+}
+
+// toVersion converts a decoded _version_ value to int64. The versions
+// exceed the float64 precision so they must be decoded as json.Number.
+func toVersion(v interface{}) (int64, error) {
+	switch val := v.(type) {
+	case json.Number:
+		return val.Int64()
+	case int64:
+		return val, nil
+	case int:
+		return int64(val), nil
+	}
+
+	return 0, fmt.Errorf("unexpected version type %T", v)
+}
+
+// GetResponse is the real-time get response
+type GetResponse struct {
+	*BaseResponse
+	// Documents is the list of documents found
+	Documents []M `json:"-"`
+}
+
+// UnmarshalJSON implements json.Unmarshaler. It handles both the single
+// document (doc) and multiple documents (response.docs) shapes. Numbers are
+// decoded as json.Number to preserve the precision of _version_.
+func (r *GetResponse) UnmarshalJSON(b []byte) error {
+	var raw struct {
+		*BaseResponse
+		Doc      M `json:"doc"`
+		Response *struct {
+			Documents []M `json:"docs"`
+		} `json:"response"`
+	}
+
+	dec := json.NewDecoder(bytes.NewReader(b))
+	dec.UseNumber()
+	err := dec.Decode(&raw)
+	if err != nil {
+		return err
+	}
+
+	r.BaseResponse = raw.BaseResponse
+	r.Documents = []M{}
+	if raw.Doc != nil {
+		r.Documents = append(r.Documents, raw.Doc)
+	}
+
+	if raw.Response != nil {
+		r.Documents = append(r.Documents, raw.Response.Documents...)
+	}
+
+	return nil
+}
+
+// DecodeDocuments decodes the documents into v, e.g. a pointer to a slice of structs
+func (r *GetResponse) DecodeDocuments(v interface{}) error {
+	return decodeDocuments(r.Documents, v)
 }
 
 // QueryResponse is a query response
@@ -36,6 +230,35 @@ type QueryResponse struct {
 	*BaseResponse
 	Response QueryResponseBody `json:"response,omitempty"`
 	Facets   M                 `json:"facets,omitempty"`
+	// Highlighting is the highlighted snippets
+	// by document id, then by field
+	Highlighting Highlighting `json:"highlighting,omitempty"`
+	// Spellcheck is the spellcheck suggestions and collations
+	Spellcheck *SpellcheckResult `json:"spellcheck,omitempty"`
+	// Grouped is the result grouping by grouping command
+	// (field, query or function)
+	Grouped map[string]GroupResult `json:"grouped,omitempty"`
+	// Expanded is the expanded documents by collapsed group value
+	Expanded map[string]QueryResponseBody `json:"expanded,omitempty"`
+}
+
+// DecodeDocumentsWithHighlighting decodes the documents into v after
+// merging in the highlighted snippets (see MergeHighlighting).
+// uniqueKey is the unique key field name.
+func (r *QueryResponse) DecodeDocumentsWithHighlighting(v interface{}, uniqueKey string) error {
+	docs := MergeHighlighting(r.Response.Documents, r.Highlighting, uniqueKey)
+	return decodeDocuments(docs, v)
+}
+
+// FacetResults returns the typed facet results, with the
+// facets of the request accessible by name through Facet.
+// It returns nil if the response has no facets.
+func (r *QueryResponse) FacetResults() *FacetResult {
+	if r.Facets == nil {
+		return nil
+	}
+
+	return NewFacetResult(r.Facets)
 }
 
 // QueryResponseBody is the query response body
@@ -46,6 +269,12 @@ type QueryResponseBody struct {
 	Documents []M     `json:"docs,omitempty"`
 }
 
+// DecodeDocuments decodes the documents into v, e.g. a pointer to a slice of structs.
+// Nested child documents are decoded into the corresponding struct fields.
+func (b *QueryResponseBody) DecodeDocuments(v interface{}) error {
+	return decodeDocuments(b.Documents, v)
+}
+
 // SuggestResponse is the suggester response
 type SuggestResponse struct {
 	*BaseResponse
@@ -107,3 +336,17 @@ type Index struct {
 	UserData                M      `json:"userData"`
 	Version                 int
 }
+
+// schemaResponse is the response of the schema read API
+type schemaResponse struct {
+	*BaseResponse
+	Schema        *Schema     `json:"schema"`
+	Fields        []Field     `json:"fields"`
+	Field         *Field      `json:"field"`
+	DynamicFields []Field     `json:"dynamicFields"`
+	FieldTypes    []FieldType `json:"fieldTypes"`
+	CopyFields    []CopyField `json:"copyFields"`
+	UniqueKey     string      `json:"uniqueKey"`
+	Version       float64     `json:"version"`
+	Similarity    *Similarity `json:"similarity"`
+}
@@ -1,9 +1,12 @@
 package solr_test
 
 import (
+	"encoding/json"
+	"fmt"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
 	"github.com/stevenferrer/solr-go"
 )
@@ -12,3 +15,98 @@ func TestResponseError(t *testing.T) {
 	err := solr.ResponseError{Msg: "an error"}
 	assert.Equal(t, "an error", err.Error())
 }
+
+func TestResponseErrorDetails(t *testing.T) {
+	var resp solr.BaseResponse
+	err := json.Unmarshal([]byte(`{"error":{"code":400,"msg":"error processing commands",
+		"details":[{"add-field":{"name":"price","type":"pfloat"},"errorMessages":["Field 'price': Field type 'pfloat' not found.\n"]},
+			{"add-copy-field":{"source":"sku","dest":"_text_"},"errorMessages":["Source field 'sku' is not a glob and doesn't match any explicit field or dynamicField.\n"]}]}}`), &resp)
+	require.NoError(t, err)
+	require.Len(t, resp.Error.Details, 2)
+	assert.Equal(t, "add-field", resp.Error.Details[0].Command)
+	assert.JSONEq(t, `{"name":"price","type":"pfloat"}`, string(resp.Error.Details[0].Body))
+	assert.Equal(t, []string{"Field 'price': Field type 'pfloat' not found.\n"}, resp.Error.Details[0].ErrorMessages)
+	assert.Equal(t, "error processing commands: add-field: Field 'price': Field type 'pfloat' not found.; "+
+		"add-copy-field: Source field 'sku' is not a glob and doesn't match any explicit field or dynamicField.", resp.Error.Error())
+
+	err = json.Unmarshal([]byte(`{"error":{"details":[{"add-field":{},"errorMessages":"not a list"}]}}`), &resp)
+	assert.Error(t, err)
+}
+
+func TestDocVersions(t *testing.T) {
+	var resp solr.UpdateResponse
+	err := json.Unmarshal([]byte(`{"adds":["1",1632740120218042368,"2",1632740120250548224],"deletes":["3",-1632740120251596800]}`), &resp)
+	require.NoError(t, err)
+
+	expectAdds := solr.DocVersions{
+		{ID: "1", Version: 1632740120218042368},
+		{ID: "2", Version: 1632740120250548224},
+	}
+	assert.Equal(t, expectAdds, resp.Adds)
+
+	expectDeletes := solr.DocVersions{{ID: "3", Version: -1632740120251596800}}
+	assert.Equal(t, expectDeletes, resp.Deletes)
+
+	err = json.Unmarshal([]byte(`{"adds":["1"]}`), &resp)
+	assert.Error(t, err)
+
+	err = json.Unmarshal([]byte(`{"adds":["1","a"]}`), &resp)
+	assert.Error(t, err)
+}
+
+func TestIsVersionConflict(t *testing.T) {
+	err := fmt.Errorf("read response: %w", &solr.VersionConflictError{
+		ResponseError: &solr.ResponseError{Code: 409, Msg: "version conflict"},
+	})
+	assert.True(t, solr.IsVersionConflict(err))
+	assert.Equal(t, "read response: version conflict", err.Error())
+
+	var respErr *solr.ResponseError
+	assert.ErrorAs(t, err, &respErr)
+
+	assert.False(t, solr.IsVersionConflict(solr.ResponseError{Msg: "an error"}))
+}
+
+func TestGetResponse(t *testing.T) {
+	type product struct {
+		ID      string `json:"id"`
+		Name    string `json:"name"`
+		Version int64  `json:"_version_"`
+	}
+
+	t.Run("single document", func(t *testing.T) {
+		var resp solr.GetResponse
+		err := json.Unmarshal([]byte(`{"doc":{"id":"1","name":"product 1","_version_":1632740120218042368}}`), &resp)
+		require.NoError(t, err)
+		require.Len(t, resp.Documents, 1)
+		assert.Equal(t, json.Number("1632740120218042368"), resp.Documents[0]["_version_"])
+
+		var products []product
+		err = resp.DecodeDocuments(&products)
+		require.NoError(t, err)
+		expect := []product{{ID: "1", Name: "product 1", Version: 1632740120218042368}}
+		assert.Equal(t, expect, products)
+	})
+
+	t.Run("multiple documents", func(t *testing.T) {
+		var resp solr.GetResponse
+		err := json.Unmarshal([]byte(`{"response":{"numFound":2,"start":0,"docs":[{"id":"1","name":"product 1"},{"id":"2","name":"product 2"}]}}`), &resp)
+		require.NoError(t, err)
+
+		var products []product
+		err = resp.DecodeDocuments(&products)
+		require.NoError(t, err)
+		expect := []product{{ID: "1", Name: "product 1"}, {ID: "2", Name: "product 2"}}
+		assert.Equal(t, expect, products)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		var resp solr.GetResponse
+		err := json.Unmarshal([]byte(`{"doc":null}`), &resp)
+		require.NoError(t, err)
+		assert.Empty(t, resp.Documents)
+
+		err = json.Unmarshal([]byte(`{"doc":1}`), &resp)
+		assert.Error(t, err)
+	})
+}
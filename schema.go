@@ -1,52 +1,110 @@
 package solr
 
+import (
+	"bytes"
+	"encoding/json"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+)
+
 // Schema is a schema
 type Schema struct {
-	Name       string      `json:"name"`
-	Version    float64     `json:"version"`
-	UniqueKey  string      `json:"uniqueKey"`
-	FieldTypes []FieldType `json:"fieldTypes,omitempty"`
-	Fields     []Field     `json:"fields,omitempty"`
-	CopyFields []CopyField `json:"copyFields"`
+	Name          string      `json:"name"`
+	Version       float64     `json:"version"`
+	UniqueKey     string      `json:"uniqueKey"`
+	Similarity    *Similarity `json:"similarity,omitempty"`
+	FieldTypes    []FieldType `json:"fieldTypes,omitempty"`
+	Fields        []Field     `json:"fields,omitempty"`
+	DynamicFields []Field     `json:"dynamicFields,omitempty"`
+	CopyFields    []CopyField `json:"copyFields"`
+}
+
+// Similarity is the similarity of the schema or field type
+type Similarity struct {
+	Class string
+	// Params is the other properties of the similarity e.g. k1 and b of BM25
+	Params M
+}
+
+var (
+	_ json.Marshaler   = Similarity{}
+	_ json.Unmarshaler = (*Similarity)(nil)
+)
+
+// MarshalJSON implements json.Marshaler
+func (s Similarity) MarshalJSON() ([]byte, error) {
+	m := M{}
+	for k, v := range s.Params {
+		m[k] = v
+	}
+	m["class"] = s.Class
+
+	return json.Marshal(m)
+}
+
+// UnmarshalJSON implements json.Unmarshaler
+func (s *Similarity) UnmarshalJSON(b []byte) error {
+	var m M
+	err := json.Unmarshal(b, &m)
+	if err != nil {
+		return err
+	}
+
+	s.Class, _ = m["class"].(string)
+	delete(m, "class")
+	s.Params = nil
+	if len(m) > 0 {
+		s.Params = m
+	}
+
+	return nil
 }
 
 // FieldType is a field type
 type FieldType struct {
-	Name                      string    `json:"name"`
-	Class                     string    `json:"class,omitempty"`
-	PositionIncrementGap      string    `json:"positionIncrementGap,omitempty"`
-	AutoGeneratePhraseQueries string    `json:"autoGeneratePhraseQueries,omitempty"`
-	SynonymQueryStyle         string    `json:"synonymQueryStyle,omitempty"`
-	EnableGraphQueries        string    `json:"enableGraphQueries,omitempty"`
-	DocValuesFormat           string    `json:"docValuesFormat,omitempty"`
-	PostingsFormat            string    `json:"postingsFormat,omitempty"`
-	Indexed                   bool      `json:"indexed,omitempty"`
-	Stored                    bool      `json:"stored,omitempty"`
-	DocValues                 bool      `json:"docValues,omitempty"`
-	SortMissingFirst          bool      `json:"sortMissingFirst,omitempty"`
-	SortMissingLast           bool      `json:"sortMissingLast,omitempty"`
-	MultiValued               bool      `json:"multiValued,omitempty"`
-	Uninvertible              bool      `json:"uninvertible,omitempty"`
-	OmitNorms                 bool      `json:"omitNorms,omitempty"`
-	OmitTermFreqAndPositions  bool      `json:"omitTermFreqAndPositions,omitempty"`
-	OmitPositions             bool      `json:"omitPositions,omitempty"`
-	TermVectors               bool      `json:"termVectors,omitempty"`
-	TermPositions             bool      `json:"termPositions,omitempty"`
-	TermOffsets               bool      `json:"termOffsets,omitempty"`
-	TermPayloads              bool      `json:"termPayloads,omitempty"`
-	Required                  bool      `json:"required,omitempty"`
-	UseDocValuesAsStored      bool      `json:"useDocValuesAsStored,omitempty"`
-	Large                     bool      `json:"large,omitempty"`
-	MaxCharsForDocValues      string    `json:"maxCharsForDocValues,omitempty"`
-	Geo                       string    `json:"geo,omitempty"`
-	MaxDistErr                string    `json:"maxDistErr,omitempty"`
-	DistErrPct                string    `json:"distErrPct,omitempty"`
-	DistanceUnits             string    `json:"distanceUnits,omitempty"`
-	SubFieldSuffix            string    `json:"subFieldSuffix,omitempty"`
-	Dimension                 string    `json:"dimension,omitempty"`
-	Analyzer                  *Analyzer `json:"analyzer,omitempty"`
-	IndexAnalyzer             *Analyzer `json:"indexAnalyzer,omitempty"`
-	QueryAnalyzer             *Analyzer `json:"queryAnalyzer,omitempty"`
+	Name                      string      `json:"name"`
+	Class                     string      `json:"class,omitempty"`
+	PositionIncrementGap      string      `json:"positionIncrementGap,omitempty"`
+	AutoGeneratePhraseQueries string      `json:"autoGeneratePhraseQueries,omitempty"`
+	SynonymQueryStyle         string      `json:"synonymQueryStyle,omitempty"`
+	EnableGraphQueries        string      `json:"enableGraphQueries,omitempty"`
+	DocValuesFormat           string      `json:"docValuesFormat,omitempty"`
+	PostingsFormat            string      `json:"postingsFormat,omitempty"`
+	Indexed                   *bool       `json:"indexed,omitempty"`
+	Stored                    *bool       `json:"stored,omitempty"`
+	DocValues                 *bool       `json:"docValues,omitempty"`
+	SortMissingFirst          *bool       `json:"sortMissingFirst,omitempty"`
+	SortMissingLast           *bool       `json:"sortMissingLast,omitempty"`
+	MultiValued               *bool       `json:"multiValued,omitempty"`
+	Uninvertible              *bool       `json:"uninvertible,omitempty"`
+	OmitNorms                 *bool       `json:"omitNorms,omitempty"`
+	OmitTermFreqAndPositions  *bool       `json:"omitTermFreqAndPositions,omitempty"`
+	OmitPositions             *bool       `json:"omitPositions,omitempty"`
+	TermVectors               *bool       `json:"termVectors,omitempty"`
+	TermPositions             *bool       `json:"termPositions,omitempty"`
+	TermOffsets               *bool       `json:"termOffsets,omitempty"`
+	TermPayloads              *bool       `json:"termPayloads,omitempty"`
+	Required                  *bool       `json:"required,omitempty"`
+	UseDocValuesAsStored      *bool       `json:"useDocValuesAsStored,omitempty"`
+	Large                     *bool       `json:"large,omitempty"`
+	MaxCharsForDocValues      string      `json:"maxCharsForDocValues,omitempty"`
+	Geo                       string      `json:"geo,omitempty"`
+	MaxDistErr                string      `json:"maxDistErr,omitempty"`
+	DistErrPct                string      `json:"distErrPct,omitempty"`
+	DistanceUnits             string      `json:"distanceUnits,omitempty"`
+	SubFieldSuffix            string      `json:"subFieldSuffix,omitempty"`
+	Dimension                 string      `json:"dimension,omitempty"`
+	VectorDimension           int         `json:"vectorDimension,omitempty"`
+	SimilarityFunction        string      `json:"similarityFunction,omitempty"`
+	KnnAlgorithm              string      `json:"knnAlgorithm,omitempty"`
+	HnswMaxConnections        int         `json:"hnswMaxConnections,omitempty"`
+	HnswBeamWidth             int         `json:"hnswBeamWidth,omitempty"`
+	Analyzer                  *Analyzer   `json:"analyzer,omitempty"`
+	IndexAnalyzer             *Analyzer   `json:"indexAnalyzer,omitempty"`
+	QueryAnalyzer             *Analyzer   `json:"queryAnalyzer,omitempty"`
+	Similarity                *Similarity `json:"similarity,omitempty"`
 }
 
 // Tokenizer is a tokenizer
@@ -100,16 +158,41 @@ type Filter struct {
 	MaxGramSize         int    `json:"maxGramSize,omitempty"`
 }
 
-// Field is a field
+// Field is a field. The boolean properties are optional, a nil
+// property is not sent and is inherited from the field type.
 type Field struct {
-	Name                 string `json:"name"`
-	Type                 string `json:"type,omitempty"`
-	DocValues            bool   `json:"docValues,omitempty"`
-	Indexed              bool   `json:"indexed,omitempty"`
-	Stored               bool   `json:"stored,omitempty"`
-	MultiValued          bool   `json:"multiValued,omitempty"`
-	Required             bool   `json:"required,omitempty"`
-	UseDocValuesAsStored bool   `json:"useDocValuesAsStored,omitempty"`
+	Name                     string `json:"name"`
+	Type                     string `json:"type,omitempty"`
+	Default                  string `json:"default,omitempty"`
+	DocValues                *bool  `json:"docValues,omitempty"`
+	Indexed                  *bool  `json:"indexed,omitempty"`
+	Stored                   *bool  `json:"stored,omitempty"`
+	MultiValued              *bool  `json:"multiValued,omitempty"`
+	Required                 *bool  `json:"required,omitempty"`
+	UseDocValuesAsStored     *bool  `json:"useDocValuesAsStored,omitempty"`
+	SortMissingFirst         *bool  `json:"sortMissingFirst,omitempty"`
+	SortMissingLast          *bool  `json:"sortMissingLast,omitempty"`
+	Uninvertible             *bool  `json:"uninvertible,omitempty"`
+	OmitNorms                *bool  `json:"omitNorms,omitempty"`
+	OmitTermFreqAndPositions *bool  `json:"omitTermFreqAndPositions,omitempty"`
+	OmitPositions            *bool  `json:"omitPositions,omitempty"`
+	TermVectors              *bool  `json:"termVectors,omitempty"`
+	TermPositions            *bool  `json:"termPositions,omitempty"`
+	TermOffsets              *bool  `json:"termOffsets,omitempty"`
+	TermPayloads             *bool  `json:"termPayloads,omitempty"`
+	Large                    *bool  `json:"large,omitempty"`
+	// Properties is the other properties of the field
+	Properties M `json:"-"`
+}
+
+// Bool returns a pointer to the bool value e.g. Field{Stored: Bool(false)}
+func Bool(v bool) *bool {
+	return &v
+}
+
+// BoolValue returns the value of the bool pointer, false if it is nil
+func BoolValue(p *bool) bool {
+	return p != nil && *p
 }
 
 // CopyField is a copy field
@@ -118,3 +201,189 @@ type CopyField struct {
 	Dest     string `json:"dest,omitempty"`
 	MaxChars int    `json:"maxchars,omitempty"`
 }
+
+// Solr returns the properties either as strings or as booleans and numbers
+// depending on how the schema was defined and whether the defaults are shown,
+// so the schema types are decoded leniently
+
+type (
+	fieldType FieldType
+	tokenizer Tokenizer
+	filter    Filter
+	field     Field
+	copyField CopyField
+)
+
+// UnmarshalJSON implements json.Unmarshaler
+func (ft *FieldType) UnmarshalJSON(b []byte) error {
+	return unmarshalLenient(b, (*fieldType)(ft))
+}
+
+// UnmarshalJSON implements json.Unmarshaler
+func (t *Tokenizer) UnmarshalJSON(b []byte) error {
+	return unmarshalLenient(b, (*tokenizer)(t))
+}
+
+// UnmarshalJSON implements json.Unmarshaler
+func (f *Filter) UnmarshalJSON(b []byte) error {
+	return unmarshalLenient(b, (*filter)(f))
+}
+
+// MarshalJSON implements json.Marshaler, the
+// properties are added after the known properties
+func (f Field) MarshalJSON() ([]byte, error) {
+	b, err := json.Marshal(field(f))
+	if err != nil {
+		return nil, err
+	}
+
+	if len(f.Properties) == 0 {
+		return b, nil
+	}
+
+	known := jsonKinds(reflect.TypeOf(field{}))
+	keys := make([]string, 0, len(f.Properties))
+	for k := range f.Properties {
+		if _, ok := known[strings.ToLower(k)]; !ok {
+			keys = append(keys, k)
+		}
+	}
+	sort.Strings(keys)
+
+	buf := bytes.NewBuffer(b[:len(b)-1])
+	for _, k := range keys {
+		v, err := json.Marshal(f.Properties[k])
+		if err != nil {
+			return nil, wrapErr(err, "marshal property "+k)
+		}
+
+		kb, _ := json.Marshal(k)
+		buf.WriteByte(',')
+		buf.Write(kb)
+		buf.WriteByte(':')
+		buf.Write(v)
+	}
+	buf.WriteByte('}')
+
+	return buf.Bytes(), nil
+}
+
+// UnmarshalJSON implements json.Unmarshaler, the unknown
+// properties e.g. dynamicBase are decoded into Properties
+func (f *Field) UnmarshalJSON(b []byte) error {
+	err := unmarshalLenient(b, (*field)(f))
+	if err != nil {
+		return err
+	}
+
+	var m M
+	err = json.Unmarshal(b, &m)
+	if err != nil {
+		return err
+	}
+
+	known := jsonKinds(reflect.TypeOf(field{}))
+	for k := range m {
+		if _, ok := known[strings.ToLower(k)]; ok {
+			delete(m, k)
+		}
+	}
+
+	f.Properties = nil
+	if len(m) > 0 {
+		f.Properties = m
+	}
+
+	return nil
+}
+
+// UnmarshalJSON implements json.Unmarshaler
+func (cf *CopyField) UnmarshalJSON(b []byte) error {
+	return unmarshalLenient(b, (*copyField)(cf))
+}
+
+// unmarshalLenient decodes the json object into the struct v, converting
+// the scalar values to the kinds of the struct fields e.g. true to "true"
+// for string fields and "100" to 100 for int fields
+func unmarshalLenient(b []byte, v interface{}) error {
+	var raw map[string]json.RawMessage
+	err := json.Unmarshal(b, &raw)
+	if err != nil {
+		return err
+	}
+
+	kinds := jsonKinds(reflect.TypeOf(v).Elem())
+	for key, val := range raw {
+		if kind, ok := kinds[strings.ToLower(key)]; ok {
+			raw[key] = coerceJSON(val, kind)
+		}
+	}
+
+	b, err = json.Marshal(raw)
+	if err != nil {
+		return err
+	}
+
+	return json.Unmarshal(b, v)
+}
+
+// jsonKinds returns the kinds of the struct fields by the lower-cased
+// json name, the keys are matched case-insensitively like encoding/json does
+func jsonKinds(rt reflect.Type) map[string]reflect.Kind {
+	kinds := map[string]reflect.Kind{}
+	for i := 0; i < rt.NumField(); i++ {
+		sf := rt.Field(i)
+		name := strings.Split(sf.Tag.Get("json"), ",")[0]
+		if name == "-" {
+			continue
+		}
+
+		typ := sf.Type
+		if typ.Kind() == reflect.Ptr {
+			typ = typ.Elem()
+		}
+		kinds[strings.ToLower(name)] = typ.Kind()
+	}
+
+	return kinds
+}
+
+// coerceJSON converts the json scalar value to the kind, it
+// returns the value as is if it can't be converted
+func coerceJSON(val json.RawMessage, kind reflect.Kind) json.RawMessage {
+	val = bytes.TrimSpace(val)
+	if len(val) == 0 || bytes.Equal(val, []byte("null")) {
+		return val
+	}
+
+	isString := val[0] == '"'
+	isScalar := val[0] != '{' && val[0] != '['
+	switch kind {
+	case reflect.String:
+		if isScalar && !isString {
+			b, _ := json.Marshal(string(val))
+			return b
+		}
+	case reflect.Bool:
+		var s string
+		if isString && json.Unmarshal(val, &s) == nil && (s == "true" || s == "false") {
+			return json.RawMessage(s)
+		}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		var s string
+		if isString && json.Unmarshal(val, &s) == nil {
+			if _, err := strconv.ParseInt(s, 10, 64); err == nil {
+				return json.RawMessage(s)
+			}
+		}
+	case reflect.Float32, reflect.Float64:
+		var s string
+		if isString && json.Unmarshal(val, &s) == nil && json.Valid([]byte(s)) {
+			if _, err := strconv.ParseFloat(s, 64); err == nil {
+				return json.RawMessage(s)
+			}
+		}
+	}
+
+	return val
+}
@@ -0,0 +1,121 @@
+package solr
+
+import "encoding/json"
+
+// SchemaBatch is a builder for a batch of schema commands that
+// are sent in a single request and applied atomically by Solr
+//
+// Refer to https://solr.apache.org/guide/8_8/schema-api.html#multiple-commands-in-a-single-post
+type SchemaBatch struct {
+	commands []SchemaCommand
+}
+
+var _ json.Marshaler = (*SchemaBatch)(nil)
+
+// NewSchemaBatch returns a new SchemaBatch
+func NewSchemaBatch() *SchemaBatch {
+	return &SchemaBatch{}
+}
+
+// AddFields adds an add-field command for each field
+func (b *SchemaBatch) AddFields(fields ...Field) *SchemaBatch {
+	for _, field := range fields {
+		b.addCommand("add-field", field)
+	}
+	return b
+}
+
+// DeleteFields adds a delete-field command for each field
+func (b *SchemaBatch) DeleteFields(names ...string) *SchemaBatch {
+	for _, name := range names {
+		b.addCommand("delete-field", M{"name": name})
+	}
+	return b
+}
+
+// ReplaceFields adds a replace-field command for each field
+func (b *SchemaBatch) ReplaceFields(fields ...Field) *SchemaBatch {
+	for _, field := range fields {
+		b.addCommand("replace-field", field)
+	}
+	return b
+}
+
+// AddDynamicFields adds an add-dynamic-field command for each dynamic field
+func (b *SchemaBatch) AddDynamicFields(fields ...Field) *SchemaBatch {
+	for _, field := range fields {
+		b.addCommand("add-dynamic-field", field)
+	}
+	return b
+}
+
+// DeleteDynamicFields adds a delete-dynamic-field command for each dynamic field
+func (b *SchemaBatch) DeleteDynamicFields(names ...string) *SchemaBatch {
+	for _, name := range names {
+		b.addCommand("delete-dynamic-field", M{"name": name})
+	}
+	return b
+}
+
+// ReplaceDynamicFields adds a replace-dynamic-field command for each dynamic field
+func (b *SchemaBatch) ReplaceDynamicFields(fields ...Field) *SchemaBatch {
+	for _, field := range fields {
+		b.addCommand("replace-dynamic-field", field)
+	}
+	return b
+}
+
+// AddFieldTypes adds an add-field-type command for each field type
+func (b *SchemaBatch) AddFieldTypes(fieldTypes ...FieldType) *SchemaBatch {
+	for _, fieldType := range fieldTypes {
+		b.addCommand("add-field-type", fieldType)
+	}
+	return b
+}
+
+// DeleteFieldTypes adds a delete-field-type command for each field type
+func (b *SchemaBatch) DeleteFieldTypes(names ...string) *SchemaBatch {
+	for _, name := range names {
+		b.addCommand("delete-field-type", M{"name": name})
+	}
+	return b
+}
+
+// ReplaceFieldTypes adds a replace-field-type command for each field type
+func (b *SchemaBatch) ReplaceFieldTypes(fieldTypes ...FieldType) *SchemaBatch {
+	for _, fieldType := range fieldTypes {
+		b.addCommand("replace-field-type", fieldType)
+	}
+	return b
+}
+
+// AddCopyFields adds an add-copy-field command for each copy field
+func (b *SchemaBatch) AddCopyFields(copyFields ...CopyField) *SchemaBatch {
+	for _, copyField := range copyFields {
+		b.addCommand("add-copy-field", copyField)
+	}
+	return b
+}
+
+// DeleteCopyFields adds a delete-copy-field command for each copy field
+func (b *SchemaBatch) DeleteCopyFields(copyFields ...CopyField) *SchemaBatch {
+	for _, copyField := range copyFields {
+		b.addCommand("delete-copy-field", CopyField{Source: copyField.Source, Dest: copyField.Dest})
+	}
+	return b
+}
+
+// Commands returns the commands in order
+func (b *SchemaBatch) Commands() []SchemaCommand {
+	return b.commands
+}
+
+func (b *SchemaBatch) addCommand(name string, body interface{}) *SchemaBatch {
+	b.commands = append(b.commands, SchemaCommand{Name: name, Body: body})
+	return b
+}
+
+// MarshalJSON implements json.Marshaler
+func (b *SchemaBatch) MarshalJSON() ([]byte, error) {
+	return marshalSchemaCommands(b.commands)
+}
@@ -0,0 +1,48 @@
+package solr_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/stevenferrer/solr-go"
+)
+
+func TestSchemaBatch(t *testing.T) {
+	batch := solr.NewSchemaBatch().
+		AddFieldTypes(solr.FieldType{Name: "pfloat", Class: "solr.FloatPointField"}).
+		ReplaceFieldTypes(solr.FieldType{Name: "string", Class: "solr.StrField"}).
+		AddFields(solr.Field{Name: "price", Type: "pfloat"}, solr.Field{Name: "sku", Type: "string"}).
+		ReplaceFields(solr.Field{Name: "name", Type: "string"}).
+		AddDynamicFields(solr.Field{Name: "*_f", Type: "pfloat"}).
+		ReplaceDynamicFields(solr.Field{Name: "*_s", Type: "string"}).
+		AddCopyFields(solr.CopyField{Source: "sku", Dest: "_text_", MaxChars: 100}).
+		DeleteCopyFields(solr.CopyField{Source: "name", Dest: "_text_", MaxChars: 100}).
+		DeleteFields("legacy").
+		DeleteDynamicFields("*_l").
+		DeleteFieldTypes("plong")
+
+	b, err := json.Marshal(batch)
+	require.NoError(t, err)
+
+	expect := `{"add-field-type":{"name":"pfloat","class":"solr.FloatPointField"},` +
+		`"replace-field-type":{"name":"string","class":"solr.StrField"},` +
+		`"add-field":{"name":"price","type":"pfloat"},` +
+		`"add-field":{"name":"sku","type":"string"},` +
+		`"replace-field":{"name":"name","type":"string"},` +
+		`"add-dynamic-field":{"name":"*_f","type":"pfloat"},` +
+		`"replace-dynamic-field":{"name":"*_s","type":"string"},` +
+		`"add-copy-field":{"source":"sku","dest":"_text_","maxchars":100},` +
+		`"delete-copy-field":{"source":"name","dest":"_text_"},` +
+		`"delete-field":{"name":"legacy"},` +
+		`"delete-dynamic-field":{"name":"*_l"},` +
+		`"delete-field-type":{"name":"plong"}}`
+	assert.Equal(t, expect, string(b))
+	assert.Len(t, batch.Commands(), 12)
+
+	b, err = json.Marshal(solr.NewSchemaBatch())
+	require.NoError(t, err)
+	assert.Equal(t, "{}", string(b))
+}
@@ -0,0 +1,313 @@
+package solr
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// SchemaCommand is a schema API command e.g. add-field with a Field
+//
+// Refer to https://solr.apache.org/guide/8_8/schema-api.html#modify-the-schema
+type SchemaCommand struct {
+	Name string
+	Body interface{}
+}
+
+// marshalSchemaCommands writes the commands in order as a single
+// object, repeating the same key which Solr allows for schema commands
+func marshalSchemaCommands(commands []SchemaCommand) ([]byte, error) {
+	buf := &bytes.Buffer{}
+	buf.WriteByte('{')
+	for i, command := range commands {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+
+		b, err := json.Marshal(command.Body)
+		if err != nil {
+			return nil, wrapErr(err, "marshal "+command.Name+" command")
+		}
+
+		buf.WriteString(`"` + command.Name + `":`)
+		buf.Write(b)
+	}
+	buf.WriteByte('}')
+
+	return buf.Bytes(), nil
+}
+
+// SchemaChange is a change of a schema plan
+type SchemaChange struct {
+	Command SchemaCommand
+	// Destructive is true if the change can lose data or leave the
+	// index inconsistent e.g. changing the type of a field on a
+	// populated index, Reason describes why
+	Destructive bool
+	Reason      string
+}
+
+// String implements Stringer
+func (c SchemaChange) String() string {
+	b, err := json.Marshal(c.Command.Body)
+	if err != nil {
+		b = []byte(fmt.Sprint(c.Command.Body))
+	}
+
+	s := c.Command.Name + " " + string(b)
+	if c.Destructive {
+		s += " (destructive: " + c.Reason + ")"
+	}
+
+	return s
+}
+
+// SchemaPlan is the ordered list of changes that brings
+// the live schema of a collection to the desired schema
+type SchemaPlan struct {
+	Changes []SchemaChange
+}
+
+// ErrDestructiveChanges is returned when applying a plan
+// with destructive changes without allowing them
+var ErrDestructiveChanges = errors.New("schema plan has destructive changes")
+
+// String renders the plan for review (dry-run), one change per line
+func (p *SchemaPlan) String() string {
+	if len(p.Changes) == 0 {
+		return "no changes"
+	}
+
+	lines := make([]string, 0, len(p.Changes))
+	for _, change := range p.Changes {
+		lines = append(lines, change.String())
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+// Commands returns the commands of the changes in order
+func (p *SchemaPlan) Commands() []SchemaCommand {
+	commands := make([]SchemaCommand, 0, len(p.Changes))
+	for _, change := range p.Changes {
+		commands = append(commands, change.Command)
+	}
+
+	return commands
+}
+
+// Destructive returns the destructive changes
+func (p *SchemaPlan) Destructive() []SchemaChange {
+	changes := []SchemaChange{}
+	for _, change := range p.Changes {
+		if change.Destructive {
+			changes = append(changes, change)
+		}
+	}
+
+	return changes
+}
+
+// PlanOptions are the options of a schema plan
+type PlanOptions struct {
+	// Prune if true, the field types, fields, dynamic fields and copy
+	// fields of the live schema which are not in the desired schema
+	// are deleted, otherwise they are kept
+	Prune bool
+}
+
+// isReservedField returns true for the fields reserved by Solr e.g. _version_
+func isReservedField(name string) bool {
+	return len(name) > 2 && strings.HasPrefix(name, "_") && strings.HasSuffix(name, "_")
+}
+
+// planSchema diffs the live schema with the desired schema. populated is
+// true if the index has documents. The changes are ordered so that each
+// command only refers to field types and fields that exist at that point:
+// field types are added and replaced first, copy fields are deleted before
+// the fields, and unused field types are deleted last. The deletes are only
+// planned if opts.Prune is true, and the reserved fields (e.g. _version_)
+// and the unique key field are never deleted.
+func planSchema(live, desired Schema, populated bool, opts PlanOptions) (*SchemaPlan, error) {
+	if desired.UniqueKey != "" && live.UniqueKey != "" && desired.UniqueKey != live.UniqueKey {
+		return nil, fmt.Errorf("unique key can't be changed from %q to %q via the schema API",
+			live.UniqueKey, desired.UniqueKey)
+	}
+
+	plan := &SchemaPlan{}
+	add := func(name string, body interface{}, destructive bool, reason string) {
+		plan.Changes = append(plan.Changes, SchemaChange{
+			Command:     SchemaCommand{Name: name, Body: body},
+			Destructive: destructive,
+			Reason:      reason,
+		})
+	}
+
+	// field types
+	liveTypes := map[string]FieldType{}
+	for _, ft := range live.FieldTypes {
+		liveTypes[ft.Name] = ft
+	}
+
+	for _, ft := range desired.FieldTypes {
+		liveType, ok := liveTypes[ft.Name]
+		if !ok {
+			add("add-field-type", ft, false, "")
+			continue
+		}
+
+		if !reflect.DeepEqual(liveType, ft) {
+			if populated {
+				add("replace-field-type", ft, true,
+					"the existing documents are not reindexed with the new field type")
+			} else {
+				add("replace-field-type", ft, false, "")
+			}
+		}
+	}
+
+	// copy fields to delete
+	desiredCopyFields := map[CopyField]bool{}
+	// the source and dest of the desired copy fields
+	desiredCopies := map[CopyField]bool{}
+	for _, cf := range desired.CopyFields {
+		desiredCopyFields[cf] = true
+		desiredCopies[CopyField{Source: cf.Source, Dest: cf.Dest}] = true
+	}
+
+	liveCopyFields := map[CopyField]bool{}
+	for _, cf := range live.CopyFields {
+		liveCopyFields[cf] = true
+		if desiredCopyFields[cf] {
+			continue
+		}
+
+		// a copy field with a different maxChars is replaced
+		if opts.Prune || desiredCopies[CopyField{Source: cf.Source, Dest: cf.Dest}] {
+			add("delete-copy-field", CopyField{Source: cf.Source, Dest: cf.Dest}, false, "")
+		}
+	}
+
+	// fields and dynamic fields, the deletes are added
+	// after all the fields are added and replaced
+	kept := map[string]bool{}
+	deletes := []SchemaChange{}
+	diffFields := func(kind string, liveFields, desiredFields []Field) {
+		liveByName := map[string]Field{}
+		for _, f := range liveFields {
+			liveByName[f.Name] = f
+		}
+
+		desiredByName := map[string]bool{}
+		for _, f := range desiredFields {
+			desiredByName[f.Name] = true
+			kept[f.Type] = true
+
+			liveField, ok := liveByName[f.Name]
+			if !ok {
+				add("add-"+kind, f, false, "")
+				continue
+			}
+
+			if !reflect.DeepEqual(liveField, f) {
+				destructive, reason := fieldChangeIsDestructive(liveField, f, populated)
+				add("replace-"+kind, f, destructive, reason)
+			}
+		}
+
+		if !opts.Prune {
+			return
+		}
+
+		for _, f := range liveFields {
+			if desiredByName[f.Name] {
+				continue
+			}
+
+			if isReservedField(f.Name) || (kind == "field" && f.Name == live.UniqueKey) {
+				kept[f.Type] = true
+				continue
+			}
+
+			change := SchemaChange{
+				Command: SchemaCommand{Name: "delete-" + kind, Body: M{"name": f.Name}},
+			}
+			if populated {
+				change.Destructive = true
+				change.Reason = "the values of the existing documents are lost"
+			}
+			deletes = append(deletes, change)
+		}
+	}
+
+	diffFields("field", live.Fields, desired.Fields)
+	diffFields("dynamic-field", live.DynamicFields, desired.DynamicFields)
+	plan.Changes = append(plan.Changes, deletes...)
+
+	// copy fields to add
+	for _, cf := range desired.CopyFields {
+		if !liveCopyFields[cf] {
+			add("add-copy-field", cf, false, "")
+		}
+	}
+
+	if !opts.Prune {
+		return plan, nil
+	}
+
+	// unused field types
+	desiredTypes := map[string]bool{}
+	for _, ft := range desired.FieldTypes {
+		desiredTypes[ft.Name] = true
+	}
+
+	deleteTypes := []string{}
+	for name := range liveTypes {
+		if !desiredTypes[name] && !kept[name] {
+			deleteTypes = append(deleteTypes, name)
+		}
+	}
+	sort.Strings(deleteTypes)
+
+	for _, name := range deleteTypes {
+		add("delete-field-type", M{"name": name}, false, "")
+	}
+
+	return plan, nil
+}
+
+// fieldChangeIsDestructive returns true if replacing the field changes
+// how the existing values are indexed or stored on a populated index
+func fieldChangeIsDestructive(live, desired Field, populated bool) (bool, string) {
+	if !populated {
+		return false, ""
+	}
+
+	switch {
+	case live.Type != desired.Type:
+		return true, fmt.Sprintf("field type changes from %q to %q on a populated index", live.Type, desired.Type)
+	case !equalBool(live.MultiValued, desired.MultiValued):
+		return true, "multiValued changes on a populated index"
+	case !equalBool(live.DocValues, desired.DocValues):
+		return true, "docValues changes on a populated index"
+	case !equalBool(live.Indexed, desired.Indexed):
+		return true, "indexed changes on a populated index"
+	case !equalBool(live.Stored, desired.Stored):
+		return true, "stored changes on a populated index"
+	}
+
+	return false, ""
+}
+
+// equalBool returns true if both are unset or set to the same value
+func equalBool(a, b *bool) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+
+	return *a == *b
+}
@@ -0,0 +1,171 @@
+package solr
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPlanSchema(t *testing.T) {
+	live := Schema{
+		UniqueKey: "id",
+		FieldTypes: []FieldType{
+			{Name: "string", Class: "solr.StrField"},
+			{Name: "plong", Class: "solr.LongPointField"},
+			{Name: "pdate", Class: "solr.DatePointField"},
+			{Name: "text", Class: "solr.TextField", PositionIncrementGap: "100"},
+		},
+		Fields: []Field{
+			{Name: "_version_", Type: "plong", Indexed: Bool(true), Stored: Bool(true)},
+			{Name: "id", Type: "string", Indexed: Bool(true), Stored: Bool(true), Required: Bool(true)},
+			{Name: "name", Type: "text", Indexed: Bool(true), Stored: Bool(true)},
+			{Name: "price", Type: "string", Stored: Bool(true)},
+			{Name: "legacy", Type: "string", Stored: Bool(true)},
+		},
+		DynamicFields: []Field{
+			{Name: "*_dt", Type: "pdate", Indexed: Bool(true)},
+		},
+		CopyFields: []CopyField{
+			{Source: "name", Dest: "_text_"},
+			{Source: "legacy", Dest: "_text_"},
+		},
+	}
+
+	desired := Schema{
+		FieldTypes: []FieldType{
+			{Name: "string", Class: "solr.StrField"},
+			{Name: "plong", Class: "solr.LongPointField"},
+			{Name: "text", Class: "solr.TextField", PositionIncrementGap: "0"},
+			{Name: "pfloat", Class: "solr.FloatPointField"},
+		},
+		Fields: []Field{
+			{Name: "id", Type: "string", Indexed: Bool(true), Stored: Bool(true), Required: Bool(true)},
+			{Name: "name", Type: "text", Indexed: Bool(true), Stored: Bool(true)},
+			{Name: "price", Type: "pfloat", Stored: Bool(true)},
+			{Name: "stock", Type: "plong", Stored: Bool(true)},
+		},
+		CopyFields: []CopyField{
+			{Source: "name", Dest: "_text_"},
+			{Source: "price", Dest: "_text_", MaxChars: 10},
+		},
+	}
+
+	t.Run("empty index", func(t *testing.T) {
+		plan, err := planSchema(live, desired, false, PlanOptions{Prune: true})
+		require.NoError(t, err)
+
+		expect := []string{
+			"replace-field-type",
+			"add-field-type",
+			"delete-copy-field",
+			"replace-field",
+			"add-field",
+			"delete-field",
+			"delete-dynamic-field",
+			"add-copy-field",
+			"delete-field-type",
+		}
+
+		got := []string{}
+		for _, command := range plan.Commands() {
+			got = append(got, command.Name)
+		}
+		assert.Equal(t, expect, got)
+		assert.Empty(t, plan.Destructive())
+
+		assert.Equal(t, `replace-field-type {"name":"text","class":"solr.TextField","positionIncrementGap":"0"}
+add-field-type {"name":"pfloat","class":"solr.FloatPointField"}
+delete-copy-field {"source":"legacy","dest":"_text_"}
+replace-field {"name":"price","type":"pfloat","stored":true}
+add-field {"name":"stock","type":"plong","stored":true}
+delete-field {"name":"legacy"}
+delete-dynamic-field {"name":"*_dt"}
+add-copy-field {"source":"price","dest":"_text_","maxchars":10}
+delete-field-type {"name":"pdate"}`, plan.String())
+	})
+
+	t.Run("populated index", func(t *testing.T) {
+		plan, err := planSchema(live, desired, true, PlanOptions{Prune: true})
+		require.NoError(t, err)
+
+		destructive := []string{}
+		for _, change := range plan.Destructive() {
+			destructive = append(destructive, change.Command.Name)
+		}
+		assert.Equal(t, []string{
+			"replace-field-type",
+			"replace-field",
+			"delete-field",
+			"delete-dynamic-field",
+		}, destructive)
+
+		assert.Contains(t, plan.String(),
+			`replace-field {"name":"price","type":"pfloat","stored":true} (destructive: field type changes from "string" to "pfloat" on a populated index)`)
+	})
+
+	t.Run("without pruning", func(t *testing.T) {
+		desired := desired
+		desired.CopyFields = []CopyField{
+			{Source: "name", Dest: "_text_", MaxChars: 100},
+			{Source: "price", Dest: "_text_", MaxChars: 10},
+		}
+
+		plan, err := planSchema(live, desired, true, PlanOptions{})
+		require.NoError(t, err)
+
+		assert.Equal(t, `replace-field-type {"name":"text","class":"solr.TextField","positionIncrementGap":"0"} `+
+			`(destructive: the existing documents are not reindexed with the new field type)
+add-field-type {"name":"pfloat","class":"solr.FloatPointField"}
+delete-copy-field {"source":"name","dest":"_text_"}
+replace-field {"name":"price","type":"pfloat","stored":true} `+
+			`(destructive: field type changes from "string" to "pfloat" on a populated index)
+add-field {"name":"stock","type":"plong","stored":true}
+add-copy-field {"source":"name","dest":"_text_","maxchars":100}
+add-copy-field {"source":"price","dest":"_text_","maxchars":10}`, plan.String())
+	})
+
+	t.Run("explicit false", func(t *testing.T) {
+		desired := Schema{
+			FieldTypes: live.FieldTypes,
+			Fields: []Field{
+				{Name: "id", Type: "string", Indexed: Bool(true), Stored: Bool(true), Required: Bool(true)},
+				{Name: "name", Type: "text", Indexed: Bool(true), Stored: Bool(true)},
+				{Name: "price", Type: "string", Stored: Bool(true), DocValues: Bool(false)},
+				{Name: "legacy", Type: "string", Stored: Bool(true)},
+			},
+			DynamicFields: live.DynamicFields,
+			CopyFields:    live.CopyFields,
+		}
+
+		plan, err := planSchema(live, desired, true, PlanOptions{})
+		require.NoError(t, err)
+		assert.Equal(t, `replace-field {"name":"price","type":"string","docValues":false,"stored":true} `+
+			`(destructive: docValues changes on a populated index)`, plan.String())
+	})
+
+	t.Run("no changes", func(t *testing.T) {
+		plan, err := planSchema(live, live, true, PlanOptions{Prune: true})
+		require.NoError(t, err)
+		assert.Empty(t, plan.Changes)
+		assert.Equal(t, "no changes", plan.String())
+	})
+
+	t.Run("unique key", func(t *testing.T) {
+		_, err := planSchema(live, Schema{UniqueKey: "sku"}, false, PlanOptions{})
+		assert.EqualError(t, err, `unique key can't be changed from "id" to "sku" via the schema API`)
+	})
+}
+
+func TestMarshalSchemaCommands(t *testing.T) {
+	b, err := marshalSchemaCommands([]SchemaCommand{
+		{Name: "add-field", Body: Field{Name: "foo", Type: "string"}},
+		{Name: "add-field", Body: Field{Name: "bar", Type: "plong"}},
+		{Name: "delete-field", Body: M{"name": "baz"}},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, `{"add-field":{"name":"foo","type":"string"},"add-field":{"name":"bar","type":"plong"},"delete-field":{"name":"baz"}}`, string(b))
+
+	_, err = marshalSchemaCommands([]SchemaCommand{{Name: "add-field", Body: make(chan int)}})
+	assert.Error(t, err)
+}
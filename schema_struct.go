@@ -0,0 +1,338 @@
+package solr
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var timeType = reflect.TypeOf(time.Time{})
+
+// SchemaFromStruct derives the fields and copy fields of the schema from the
+// struct type. The field names are taken from the json tags, like the documents
+// are, and the properties from the solr tags e.g.
+//
+//	type Product struct {
+//		ID    string   `json:"id" solr:"required"`
+//		Name  string   `json:"name" solr:"type=text_general,copyField=_text_"`
+//		Price float64  `json:"price" solr:"docValues,stored=false"`
+//		Tags  []string `json:"tags"`
+//		Notes string   `json:"-"`
+//	}
+//
+// The type is inferred from the kind of the struct field (e.g. pdouble for
+// float64 and pdate for time.Time) unless it is set with type=<field type>.
+// Slices are multiValued. The fields with an inferred type are indexed, stored
+// and have docValues, except []byte fields which are binary and only stored,
+// and the fields with type=<field type> inherit them from the field type.
+//
+// The boolean properties (indexed, stored, docValues, multiValued, required,
+// useDocValuesAsStored, sortMissingFirst, sortMissingLast, uninvertible,
+// omitNorms, termVectors and large) are set to true if present or to the
+// value after = e.g. stored=false. default=<value> sets the default value
+// and copyField=<dest>, which can be repeated, adds a copy field to the
+// destination. Fields with the tag solr:"-" and struct fields e.g. child
+// documents are skipped. The fields of embedded structs are promoted like
+// encoding/json does, so a field hides the fields with the same name of
+// the embedded structs.
+//
+// The schema has no field types and dynamic fields, set them before
+// planning the changes with PlanOptions.Prune otherwise they are planned
+// to be deleted.
+func SchemaFromStruct(t reflect.Type) (*Schema, error) {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+
+	if t.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("expecting a struct but got %s", t)
+	}
+
+	fields, err := structFields(t)
+	if err != nil {
+		return nil, err
+	}
+
+	schema := &Schema{}
+	for _, f := range fields {
+		schema.Fields = append(schema.Fields, f.field)
+		for _, dest := range f.copyFields {
+			schema.CopyFields = append(schema.CopyFields, CopyField{Source: f.field.Name, Dest: dest})
+		}
+	}
+
+	return schema, nil
+}
+
+// structField is a schema field derived from a struct field
+type structField struct {
+	field      Field
+	copyFields []string
+	// index is the index sequence of the struct field
+	index  []int
+	tagged bool
+}
+
+// structFields returns the schema fields of the struct type in the order of
+// the struct fields. Like encoding/json, the embedded structs are visited
+// breadth first and once, and of the fields with the same name, the one at the
+// shallowest depth wins, or the tagged one if there are several at that depth.
+// If there is no single winner, the fields are skipped.
+func structFields(t reflect.Type) ([]structField, error) {
+	type embeddedStruct struct {
+		typ   reflect.Type
+		index []int
+	}
+
+	fields := []structField{}
+	taken := map[string]bool{}
+	visited := map[reflect.Type]bool{}
+	next := []embeddedStruct{{typ: t}}
+	for len(next) > 0 {
+		current := next
+		next = nil
+
+		// the fields with the same name at this depth
+		byName := map[string][]structField{}
+		names := []string{}
+		for _, e := range current {
+			if visited[e.typ] {
+				continue
+			}
+			visited[e.typ] = true
+
+			for i := 0; i < e.typ.NumField(); i++ {
+				sf := e.typ.Field(i)
+				solrTag := sf.Tag.Get("solr")
+				if solrTag == "-" {
+					continue
+				}
+
+				name := strings.Split(sf.Tag.Get("json"), ",")[0]
+				if name == "-" {
+					continue
+				}
+
+				index := make([]int, len(e.index)+1)
+				copy(index, e.index)
+				index[len(e.index)] = i
+
+				typ := sf.Type
+				for typ.Kind() == reflect.Ptr {
+					typ = typ.Elem()
+				}
+
+				// embedded structs are flattened, like encoding/json does
+				if sf.Anonymous && name == "" && typ.Kind() == reflect.Struct {
+					next = append(next, embeddedStruct{typ: typ, index: index})
+					continue
+				}
+
+				if !sf.IsExported() {
+					continue
+				}
+
+				f := structField{field: Field{Name: name}, index: index, tagged: name != ""}
+				if name == "" {
+					f.field.Name = sf.Name
+				}
+
+				if typ.Kind() == reflect.Slice && typ.Elem().Kind() != reflect.Uint8 {
+					f.field.MultiValued = Bool(true)
+					typ = typ.Elem()
+					for typ.Kind() == reflect.Ptr {
+						typ = typ.Elem()
+					}
+				}
+
+				var err error
+				f.copyFields, err = parseSolrTag(solrTag, &f.field)
+				if err != nil {
+					return nil, wrapErr(err, "field "+sf.Name)
+				}
+
+				if f.field.Type == "" {
+					// child documents
+					if typ.Kind() == reflect.Struct && typ != timeType {
+						continue
+					}
+
+					f.field.Type, err = inferFieldType(typ)
+					if err != nil {
+						return nil, wrapErr(err, "field "+sf.Name)
+					}
+					inferFieldProperties(&f.field)
+				}
+
+				if _, ok := byName[f.field.Name]; !ok {
+					names = append(names, f.field.Name)
+				}
+				byName[f.field.Name] = append(byName[f.field.Name], f)
+			}
+		}
+
+		for _, name := range names {
+			// the fields at a shallower depth win
+			if taken[name] {
+				continue
+			}
+			taken[name] = true
+
+			f, ok := dominantField(byName[name])
+			if ok {
+				fields = append(fields, f)
+			}
+		}
+	}
+
+	sort.Slice(fields, func(i, j int) bool {
+		return lessIndex(fields[i].index, fields[j].index)
+	})
+
+	return fields, nil
+}
+
+// dominantField returns the field which wins of the fields with the same
+// name at the same depth, which is the only field or the only tagged field
+func dominantField(fields []structField) (structField, bool) {
+	if len(fields) == 1 {
+		return fields[0], true
+	}
+
+	tagged := []structField{}
+	for _, f := range fields {
+		if f.tagged {
+			tagged = append(tagged, f)
+		}
+	}
+
+	if len(tagged) == 1 {
+		return tagged[0], true
+	}
+
+	return structField{}, false
+}
+
+// lessIndex compares the index sequences of two struct fields
+func lessIndex(a, b []int) bool {
+	for k := 0; k < len(a) && k < len(b); k++ {
+		if a[k] != b[k] {
+			return a[k] < b[k]
+		}
+	}
+
+	return len(a) < len(b)
+}
+
+// parseSolrTag sets the properties of the field from the solr tag
+// and returns the destinations of the copy fields
+func parseSolrTag(tag string, field *Field) ([]string, error) {
+	bools := map[string]**bool{
+		"indexed":              &field.Indexed,
+		"stored":               &field.Stored,
+		"docValues":            &field.DocValues,
+		"multiValued":          &field.MultiValued,
+		"required":             &field.Required,
+		"useDocValuesAsStored": &field.UseDocValuesAsStored,
+		"sortMissingFirst":     &field.SortMissingFirst,
+		"sortMissingLast":      &field.SortMissingLast,
+		"uninvertible":         &field.Uninvertible,
+		"omitNorms":            &field.OmitNorms,
+		"termVectors":          &field.TermVectors,
+		"large":                &field.Large,
+	}
+
+	copyFields := []string{}
+	for _, opt := range strings.Split(tag, ",") {
+		opt = strings.TrimSpace(opt)
+		if opt == "" {
+			continue
+		}
+
+		key, val, hasVal := strings.Cut(opt, "=")
+		switch key {
+		case "type":
+			field.Type = val
+		case "default":
+			field.Default = val
+		case "copyField":
+			if val == "" {
+				return nil, fmt.Errorf("copyField destination is required")
+			}
+			copyFields = append(copyFields, val)
+		default:
+			p, ok := bools[key]
+			if !ok {
+				return nil, fmt.Errorf("unknown solr tag option %q", key)
+			}
+
+			b := true
+			if hasVal {
+				var err error
+				b, err = strconv.ParseBool(val)
+				if err != nil {
+					return nil, fmt.Errorf("invalid value %q for %s", val, key)
+				}
+			}
+			*p = Bool(b)
+		}
+	}
+
+	return copyFields, nil
+}
+
+// inferFieldType returns the field type of the default configset for the go type
+func inferFieldType(t reflect.Type) (string, error) {
+	if t == timeType {
+		return "pdate", nil
+	}
+
+	switch t.Kind() {
+	case reflect.String:
+		return "string", nil
+	case reflect.Bool:
+		return "boolean", nil
+	case reflect.Int8, reflect.Int16, reflect.Int32,
+		reflect.Uint8, reflect.Uint16:
+		return "pint", nil
+	case reflect.Int, reflect.Int64, reflect.Uint,
+		reflect.Uint32, reflect.Uint64:
+		return "plong", nil
+	case reflect.Float32:
+		return "pfloat", nil
+	case reflect.Float64:
+		return "pdouble", nil
+	case reflect.Slice:
+		if t.Elem().Kind() == reflect.Uint8 {
+			return "binary", nil
+		}
+	}
+
+	return "", fmt.Errorf("can't infer the field type of %s, set it with the type option", t)
+}
+
+// inferFieldProperties sets indexed, stored and docValues of the field with
+// an inferred field type, unless they are set with the solr tag. The fields
+// are indexed, stored and have docValues, except binary fields which are
+// only stored since binary fields can't be indexed and have no docValues.
+func inferFieldProperties(field *Field) {
+	indexed, docValues := true, true
+	if field.Type == "binary" {
+		indexed, docValues = false, false
+	}
+
+	if field.Indexed == nil {
+		field.Indexed = Bool(indexed)
+	}
+
+	if field.Stored == nil {
+		field.Stored = Bool(true)
+	}
+
+	if field.DocValues == nil {
+		field.DocValues = Bool(docValues)
+	}
+}
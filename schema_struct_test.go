@@ -0,0 +1,145 @@
+package solr_test
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/stevenferrer/solr-go"
+)
+
+func TestSchemaFromStruct(t *testing.T) {
+	type Timestamps struct {
+		CreatedAt time.Time  `json:"createdAt" solr:"docValues"`
+		UpdatedAt *time.Time `json:"updatedAt,omitempty"`
+	}
+
+	type Review struct {
+		ID     string `json:"id"`
+		Rating int    `json:"rating"`
+	}
+
+	type Product struct {
+		Timestamps
+		ID          string    `json:"id" solr:"required"`
+		Name        string    `json:"name" solr:"type=text_general,copyField=_text_,copyField=name_sort"`
+		Price       float64   `json:"price" solr:"docValues,stored=false,default=0"`
+		Weight      float32   `json:"weight"`
+		Stock       int64     `json:"stock" solr:"indexed=false"`
+		Rank        int32     `json:"rank"`
+		InStock     bool      `json:"inStock"`
+		Tags        []string  `json:"tags" solr:"sortMissingLast"`
+		Thumbnail   []byte    `json:"thumbnail"`
+		Reviews     []*Review `json:"reviews"`
+		Description string
+		Internal    string `json:"-"`
+		Cached      string `json:"cached" solr:"-"`
+		secret      string
+	}
+
+	schema, err := solr.SchemaFromStruct(reflect.TypeOf(&Product{}))
+	require.NoError(t, err)
+
+	yes, no := solr.Bool(true), solr.Bool(false)
+	expectFields := []solr.Field{
+		{Name: "createdAt", Type: "pdate", Indexed: yes, Stored: yes, DocValues: yes},
+		{Name: "updatedAt", Type: "pdate", Indexed: yes, Stored: yes, DocValues: yes},
+		{Name: "id", Type: "string", Indexed: yes, Stored: yes, DocValues: yes, Required: yes},
+		{Name: "name", Type: "text_general"},
+		{Name: "price", Type: "pdouble", Indexed: yes, Stored: no, DocValues: yes, Default: "0"},
+		{Name: "weight", Type: "pfloat", Indexed: yes, Stored: yes, DocValues: yes},
+		{Name: "stock", Type: "plong", Indexed: no, Stored: yes, DocValues: yes},
+		{Name: "rank", Type: "pint", Indexed: yes, Stored: yes, DocValues: yes},
+		{Name: "inStock", Type: "boolean", Indexed: yes, Stored: yes, DocValues: yes},
+		{Name: "tags", Type: "string", Indexed: yes, Stored: yes, DocValues: yes,
+			MultiValued: yes, SortMissingLast: yes},
+		{Name: "thumbnail", Type: "binary", Indexed: no, Stored: yes, DocValues: no},
+		{Name: "Description", Type: "string", Indexed: yes, Stored: yes, DocValues: yes},
+	}
+	assert.Equal(t, expectFields, schema.Fields)
+
+	expectCopyFields := []solr.CopyField{
+		{Source: "name", Dest: "_text_"},
+		{Source: "name", Dest: "name_sort"},
+	}
+	assert.Equal(t, expectCopyFields, schema.CopyFields)
+
+	t.Run("errors", func(t *testing.T) {
+		_, err := solr.SchemaFromStruct(reflect.TypeOf(""))
+		assert.EqualError(t, err, "expecting a struct but got string")
+
+		_, err = solr.SchemaFromStruct(reflect.TypeOf(struct {
+			Attrs map[string]string `json:"attrs"`
+		}{}))
+		assert.EqualError(t, err, "field Attrs: can't infer the field type of map[string]string, set it with the type option")
+
+		_, err = solr.SchemaFromStruct(reflect.TypeOf(struct {
+			Name string `json:"name" solr:"analyzed"`
+		}{}))
+		assert.EqualError(t, err, `field Name: unknown solr tag option "analyzed"`)
+
+		_, err = solr.SchemaFromStruct(reflect.TypeOf(struct {
+			Name string `json:"name" solr:"stored=maybe"`
+		}{}))
+		assert.EqualError(t, err, `field Name: invalid value "maybe" for stored`)
+
+		_, err = solr.SchemaFromStruct(reflect.TypeOf(struct {
+			Name string `json:"name" solr:"copyField="`
+		}{}))
+		assert.EqualError(t, err, "field Name: copyField destination is required")
+
+		_, err = solr.SchemaFromStruct(reflect.TypeOf(struct {
+			Matrix [][]string `json:"matrix"`
+		}{}))
+		assert.EqualError(t, err, "field Matrix: can't infer the field type of []string, set it with the type option")
+
+		_, err = solr.SchemaFromStruct(reflect.TypeOf(struct {
+			Timestamps `solr:"-"`
+			Bad        Timestamps `json:"bad" solr:"docValues,foo"`
+		}{}))
+		assert.EqualError(t, err, `field Bad: unknown solr tag option "foo"`)
+	})
+
+	t.Run("duplicate names", func(t *testing.T) {
+		type Audit struct {
+			Timestamps
+			CreatedAt string `json:"createdAt"`
+			Label     string
+		}
+
+		type Other struct {
+			Label string
+		}
+
+		schema, err := solr.SchemaFromStruct(reflect.TypeOf(struct {
+			Audit
+			Other
+			Name  string
+			Title string `json:"Name"`
+		}{}))
+		require.NoError(t, err)
+
+		// the shallower createdAt wins, the names at the same depth
+		// are skipped and the tagged Title wins over Name
+		require.Len(t, schema.Fields, 3)
+		assert.Equal(t, "updatedAt", schema.Fields[0].Name)
+		assert.Equal(t, "createdAt", schema.Fields[1].Name)
+		assert.Equal(t, "string", schema.Fields[1].Type)
+		assert.Equal(t, "Name", schema.Fields[2].Name)
+	})
+
+	t.Run("recursive embedding", func(t *testing.T) {
+		type Node struct {
+			*Node
+			ID string `json:"id"`
+		}
+
+		schema, err := solr.SchemaFromStruct(reflect.TypeOf(Node{}))
+		require.NoError(t, err)
+		require.Len(t, schema.Fields, 1)
+		assert.Equal(t, "id", schema.Fields[0].Name)
+	})
+}
@@ -0,0 +1,123 @@
+package solr_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/stevenferrer/solr-go"
+)
+
+func TestSimilarity(t *testing.T) {
+	similarity := solr.Similarity{
+		Class:  "solr.BM25SimilarityFactory",
+		Params: solr.M{"k1": 1.2, "b": 0.75},
+	}
+
+	b, err := json.Marshal(similarity)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"class":"solr.BM25SimilarityFactory","k1":1.2,"b":0.75}`, string(b))
+
+	var got solr.Similarity
+	err = json.Unmarshal(b, &got)
+	require.NoError(t, err)
+	assert.Equal(t, similarity, got)
+
+	err = json.Unmarshal([]byte(`{"class":"solr.SchemaSimilarityFactory"}`), &got)
+	require.NoError(t, err)
+	assert.Equal(t, solr.Similarity{Class: "solr.SchemaSimilarityFactory"}, got)
+}
+
+func TestSchemaDecoding(t *testing.T) {
+	t.Run("lenient", func(t *testing.T) {
+		var fieldType solr.FieldType
+		err := json.Unmarshal([]byte(`{"name":"text","class":"solr.TextField",
+			"positionIncrementGap":100,"enableGraphQueries":true,"indexed":"true","stored":"false",
+			"analyzer":{"tokenizer":{"class":"solr.StandardTokenizerFactory"},
+				"filters":[{"class":"solr.NGramFilterFactory","minGramSize":"2","maxGramSize":3,"ignoreCase":true}]},
+			"similarity":{"class":"solr.BM25SimilarityFactory","k1":"1.2"}}`), &fieldType)
+		require.NoError(t, err)
+
+		expect := solr.FieldType{
+			Name:                 "text",
+			Class:                "solr.TextField",
+			PositionIncrementGap: "100",
+			EnableGraphQueries:   "true",
+			Indexed:              solr.Bool(true),
+			Stored:               solr.Bool(false),
+			Analyzer: &solr.Analyzer{
+				Tokenizer: &solr.Tokenizer{Class: "solr.StandardTokenizerFactory"},
+				Filters: []solr.Filter{
+					{Class: "solr.NGramFilterFactory", MinGramSize: 2, MaxGramSize: 3, IgnoreCase: "true"},
+				},
+			},
+			Similarity: &solr.Similarity{Class: "solr.BM25SimilarityFactory", Params: solr.M{"k1": "1.2"}},
+		}
+		assert.Equal(t, expect, fieldType)
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		var field solr.Field
+		err := json.Unmarshal([]byte(`{"name":"id","indexed":"yes"}`), &field)
+		assert.Error(t, err)
+
+		var copyField solr.CopyField
+		err = json.Unmarshal([]byte(`{"source":"a","dest":"b","maxChars":"many"}`), &copyField)
+		assert.Error(t, err)
+
+		err = json.Unmarshal([]byte(`[]`), &field)
+		assert.Error(t, err)
+	})
+}
+
+func TestField(t *testing.T) {
+	t.Run("marshal", func(t *testing.T) {
+		field := solr.Field{
+			Name:            "price",
+			Type:            "pfloat",
+			Default:         "0",
+			Stored:          solr.Bool(false),
+			DocValues:       solr.Bool(true),
+			SortMissingLast: solr.Bool(true),
+			Uninvertible:    solr.Bool(false),
+			OmitNorms:       solr.Bool(true),
+			TermVectors:     solr.Bool(false),
+			Large:           solr.Bool(false),
+			Properties:      solr.M{"precisionStep": 8, "stored": true},
+		}
+
+		b, err := json.Marshal(field)
+		require.NoError(t, err)
+
+		expect := `{"name":"price","type":"pfloat","default":"0","docValues":true,"stored":false,` +
+			`"sortMissingLast":true,"uninvertible":false,"omitNorms":true,"termVectors":false,"large":false,` +
+			`"precisionStep":8}`
+		assert.Equal(t, expect, string(b))
+
+		_, err = json.Marshal(solr.Field{Name: "price", Properties: solr.M{"foo": make(chan int)}})
+		assert.Error(t, err)
+	})
+
+	t.Run("unmarshal", func(t *testing.T) {
+		var field solr.Field
+		err := json.Unmarshal([]byte(`{"name":"price","type":"pfloat","stored":false,"omitNorms":"true","precisionStep":"8"}`), &field)
+		require.NoError(t, err)
+
+		expect := solr.Field{
+			Name:       "price",
+			Type:       "pfloat",
+			Stored:     solr.Bool(false),
+			OmitNorms:  solr.Bool(true),
+			Properties: solr.M{"precisionStep": "8"},
+		}
+		assert.Equal(t, expect, field)
+	})
+
+	t.Run("bool", func(t *testing.T) {
+		assert.True(t, solr.BoolValue(solr.Bool(true)))
+		assert.False(t, solr.BoolValue(solr.Bool(false)))
+		assert.False(t, solr.BoolValue(nil))
+	})
+}
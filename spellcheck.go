@@ -0,0 +1,354 @@
+package solr
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+)
+
+// Spellcheck is the spellcheck param builder
+//
+// Refer to https://solr.apache.org/guide/8_8/spell-checking.html
+type Spellcheck struct {
+	q                      string
+	dictionaries           []string
+	count                  int
+	collate                bool
+	maxCollations          int
+	maxCollationTries      int
+	collateExtendedResults bool
+	onlyMorePopular        bool
+	extendedResults        bool
+}
+
+// NewSpellcheck returns a new Spellcheck
+func NewSpellcheck() *Spellcheck {
+	return &Spellcheck{}
+}
+
+// Query sets the query to spellcheck (spellcheck.q),
+// defaults to the main query
+func (s *Spellcheck) Query(q string) *Spellcheck {
+	s.q = q
+	return s
+}
+
+// Dictionaries sets the dictionaries to use
+func (s *Spellcheck) Dictionaries(dictionaries ...string) *Spellcheck {
+	s.dictionaries = dictionaries
+	return s
+}
+
+// Count sets the maximum number of suggestions per term
+func (s *Spellcheck) Count(count int) *Spellcheck {
+	s.count = count
+	return s
+}
+
+// Collate if true, returns the best suggestions
+// combined into a new query (collation)
+func (s *Spellcheck) Collate(collate bool) *Spellcheck {
+	s.collate = collate
+	return s
+}
+
+// MaxCollations sets the maximum number of collations to return
+func (s *Spellcheck) MaxCollations(maxCollations int) *Spellcheck {
+	s.maxCollations = maxCollations
+	return s
+}
+
+// MaxCollationTries sets the number of collations to test against the index
+// so that only collations that return results are returned
+func (s *Spellcheck) MaxCollationTries(maxCollationTries int) *Spellcheck {
+	s.maxCollationTries = maxCollationTries
+	return s
+}
+
+// CollateExtendedResults if true, returns the hits and
+// the corrections of each collation
+func (s *Spellcheck) CollateExtendedResults(collateExtendedResults bool) *Spellcheck {
+	s.collateExtendedResults = collateExtendedResults
+	return s
+}
+
+// OnlyMorePopular if true, only returns suggestions that
+// are more frequent than the original term
+func (s *Spellcheck) OnlyMorePopular(onlyMorePopular bool) *Spellcheck {
+	s.onlyMorePopular = onlyMorePopular
+	return s
+}
+
+// ExtendedResults if true, returns the frequency of each suggestion
+func (s *Spellcheck) ExtendedResults(extendedResults bool) *Spellcheck {
+	s.extendedResults = extendedResults
+	return s
+}
+
+// BuildParams builds the spellcheck params
+func (s *Spellcheck) BuildParams() M {
+	m := M{"spellcheck": true}
+
+	if s.q != "" {
+		m["spellcheck.q"] = s.q
+	}
+
+	if len(s.dictionaries) > 0 {
+		m["spellcheck.dictionary"] = s.dictionaries
+	}
+
+	if s.count > 0 {
+		m["spellcheck.count"] = s.count
+	}
+
+	if s.collate {
+		m["spellcheck.collate"] = true
+	}
+
+	if s.maxCollations > 0 {
+		m["spellcheck.maxCollations"] = s.maxCollations
+	}
+
+	if s.maxCollationTries > 0 {
+		m["spellcheck.maxCollationTries"] = s.maxCollationTries
+	}
+
+	if s.collateExtendedResults {
+		m["spellcheck.collateExtendedResults"] = true
+	}
+
+	if s.onlyMorePopular {
+		m["spellcheck.onlyMorePopular"] = true
+	}
+
+	if s.extendedResults {
+		m["spellcheck.extendedResults"] = true
+	}
+
+	return m
+}
+
+// SpellcheckResult is the spellcheck section of the query response
+type SpellcheckResult struct {
+	// Suggestions is the list of suggestions for each misspelled term
+	Suggestions []SpellcheckSuggestion
+	// CorrectlySpelled is only available with extendedResults
+	CorrectlySpelled bool
+	// Collations is the list of collations, best first
+	Collations []SpellcheckCollation
+}
+
+// SpellcheckSuggestion is the suggestions for a misspelled term
+type SpellcheckSuggestion struct {
+	Term        string
+	NumFound    int              `json:"numFound"`
+	StartOffset int              `json:"startOffset"`
+	EndOffset   int              `json:"endOffset"`
+	OrigFreq    int              `json:"origFreq"`
+	Suggestions []SpellcheckWord `json:"-"`
+}
+
+// SpellcheckWord is a suggested word, Freq is
+// only available with extendedResults
+type SpellcheckWord struct {
+	Word string `json:"word"`
+	Freq int    `json:"freq"`
+}
+
+// SpellcheckCollation is a collation, Hits and MisspellingsAndCorrections
+// are only available with collateExtendedResults
+type SpellcheckCollation struct {
+	CollationQuery string
+	Hits           int
+	// MisspellingsAndCorrections is the map of misspelled term to correction
+	MisspellingsAndCorrections map[string]string
+}
+
+// UnmarshalJSON implements json.Unmarshaler. It handles the flat
+// (json.nl=flat) and map (json.nl=map) named list styles, as well
+// as the normal and extended result shapes.
+func (r *SpellcheckResult) UnmarshalJSON(b []byte) error {
+	var raw struct {
+		Suggestions      json.RawMessage `json:"suggestions"`
+		CorrectlySpelled bool            `json:"correctlySpelled"`
+		Collations       json.RawMessage `json:"collations"`
+	}
+
+	err := json.Unmarshal(b, &raw)
+	if err != nil {
+		return err
+	}
+
+	r.CorrectlySpelled = raw.CorrectlySpelled
+
+	suggestions, err := decodeNamedList(raw.Suggestions)
+	if err != nil {
+		return wrapErr(err, "decode suggestions")
+	}
+
+	r.Suggestions = make([]SpellcheckSuggestion, 0, len(suggestions))
+	for _, nv := range suggestions {
+		var suggestion SpellcheckSuggestion
+		err = json.Unmarshal(nv.value, &suggestion)
+		if err != nil {
+			return wrapErr(err, "decode suggestion")
+		}
+		suggestion.Term = nv.name
+
+		var words struct {
+			Suggestion []json.RawMessage `json:"suggestion"`
+		}
+		err = json.Unmarshal(nv.value, &words)
+		if err != nil {
+			return wrapErr(err, "decode suggestion words")
+		}
+
+		for _, w := range words.Suggestion {
+			word := SpellcheckWord{}
+			if bytes.HasPrefix(bytes.TrimSpace(w), []byte("{")) {
+				err = json.Unmarshal(w, &word)
+			} else {
+				err = json.Unmarshal(w, &word.Word)
+			}
+
+			if err != nil {
+				return wrapErr(err, "decode suggestion word")
+			}
+
+			suggestion.Suggestions = append(suggestion.Suggestions, word)
+		}
+
+		r.Suggestions = append(r.Suggestions, suggestion)
+	}
+
+	collations, err := decodeNamedList(raw.Collations)
+	if err != nil {
+		return wrapErr(err, "decode collations")
+	}
+
+	r.Collations = make([]SpellcheckCollation, 0, len(collations))
+	for _, nv := range collations {
+		collation, err := decodeCollation(nv.value)
+		if err != nil {
+			return wrapErr(err, "decode collation")
+		}
+
+		r.Collations = append(r.Collations, collation)
+	}
+
+	return nil
+}
+
+// BestCollation returns the best collation query, or empty if there is none
+func (r *SpellcheckResult) BestCollation() string {
+	if r == nil || len(r.Collations) == 0 {
+		return ""
+	}
+
+	return r.Collations[0].CollationQuery
+}
+
+// decodeCollation decodes a collation, either a plain
+// collation query string or an extended collation object
+func decodeCollation(b json.RawMessage) (SpellcheckCollation, error) {
+	var collation SpellcheckCollation
+	if !bytes.HasPrefix(bytes.TrimSpace(b), []byte("{")) {
+		err := json.Unmarshal(b, &collation.CollationQuery)
+		return collation, err
+	}
+
+	var extended struct {
+		CollationQuery             string          `json:"collationQuery"`
+		Hits                       int             `json:"hits"`
+		MisspellingsAndCorrections json.RawMessage `json:"misspellingsAndCorrections"`
+	}
+	err := json.Unmarshal(b, &extended)
+	if err != nil {
+		return collation, err
+	}
+
+	collation.CollationQuery = extended.CollationQuery
+	collation.Hits = extended.Hits
+
+	corrections, err := decodeNamedList(extended.MisspellingsAndCorrections)
+	if err != nil {
+		return collation, err
+	}
+
+	collation.MisspellingsAndCorrections = map[string]string{}
+	for _, nv := range corrections {
+		var correction string
+		err = json.Unmarshal(nv.value, &correction)
+		if err != nil {
+			return collation, err
+		}
+		collation.MisspellingsAndCorrections[nv.name] = correction
+	}
+
+	return collation, nil
+}
+
+// namedValue is an entry of a Solr named list
+type namedValue struct {
+	name  string
+	value json.RawMessage
+}
+
+// decodeNamedList decodes a Solr named list, which is serialized either
+// as a flat list of alternating names and values or as an object
+func decodeNamedList(b json.RawMessage) ([]namedValue, error) {
+	b = bytes.TrimSpace(b)
+	if len(b) == 0 || bytes.Equal(b, []byte("null")) {
+		return nil, nil
+	}
+
+	if b[0] == '[' {
+		var flat []json.RawMessage
+		err := json.Unmarshal(b, &flat)
+		if err != nil {
+			return nil, err
+		}
+
+		if len(flat)%2 != 0 {
+			return nil, fmt.Errorf("expecting name and value pairs but got %d items", len(flat))
+		}
+
+		list := make([]namedValue, 0, len(flat)/2)
+		for i := 0; i < len(flat); i += 2 {
+			var name string
+			err = json.Unmarshal(flat[i], &name)
+			if err != nil {
+				return nil, err
+			}
+
+			list = append(list, namedValue{name: name, value: flat[i+1]})
+		}
+
+		return list, nil
+	}
+
+	// preserve the order of the keys
+	dec := json.NewDecoder(bytes.NewReader(b))
+	_, err := dec.Token()
+	if err != nil {
+		return nil, err
+	}
+
+	list := []namedValue{}
+	for dec.More() {
+		tok, err := dec.Token()
+		if err != nil {
+			return nil, err
+		}
+
+		var value json.RawMessage
+		err = dec.Decode(&value)
+		if err != nil {
+			return nil, err
+		}
+
+		list = append(list, namedValue{name: fmt.Sprint(tok), value: value})
+	}
+
+	return list, nil
+}
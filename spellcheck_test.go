@@ -0,0 +1,129 @@
+package solr_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/stevenferrer/solr-go"
+)
+
+func TestSpellcheck(t *testing.T) {
+	got := solr.NewSpellcheck().BuildParams()
+	assert.Equal(t, solr.M{"spellcheck": true}, got)
+
+	got = solr.NewSpellcheck().
+		Query("delll ultra sharp").
+		Dictionaries("default", "wordbreak").
+		Count(10).
+		Collate(true).
+		MaxCollations(3).
+		MaxCollationTries(5).
+		CollateExtendedResults(true).
+		OnlyMorePopular(true).
+		ExtendedResults(true).
+		BuildParams()
+
+	expect := solr.M{
+		"spellcheck":                        true,
+		"spellcheck.q":                      "delll ultra sharp",
+		"spellcheck.dictionary":             []string{"default", "wordbreak"},
+		"spellcheck.count":                  10,
+		"spellcheck.collate":                true,
+		"spellcheck.maxCollations":          3,
+		"spellcheck.maxCollationTries":      5,
+		"spellcheck.collateExtendedResults": true,
+		"spellcheck.onlyMorePopular":        true,
+		"spellcheck.extendedResults":        true,
+	}
+	assert.Equal(t, expect, got)
+
+	query := solr.NewQuery("delll").
+		Spellcheck(solr.NewSpellcheck().Collate(true)).
+		BuildQuery()
+	assert.Equal(t, solr.M{"spellcheck": true, "spellcheck.collate": true}, query["params"])
+}
+
+func TestSpellcheckResult(t *testing.T) {
+	t.Run("flat", func(t *testing.T) {
+		body := `{"spellcheck":{
+			"suggestions":["delll",{"numFound":1,"startOffset":0,"endOffset":5,"suggestion":["dell"]}],
+			"collations":["collation","dell"]
+		}}`
+
+		var resp solr.QueryResponse
+		err := json.Unmarshal([]byte(body), &resp)
+		require.NoError(t, err)
+
+		expect := &solr.SpellcheckResult{
+			Suggestions: []solr.SpellcheckSuggestion{
+				{
+					Term:        "delll",
+					NumFound:    1,
+					EndOffset:   5,
+					Suggestions: []solr.SpellcheckWord{{Word: "dell"}},
+				},
+			},
+			Collations: []solr.SpellcheckCollation{{CollationQuery: "dell"}},
+		}
+		assert.Equal(t, expect, resp.Spellcheck)
+		assert.Equal(t, "dell", resp.Spellcheck.BestCollation())
+	})
+
+	t.Run("extended", func(t *testing.T) {
+		body := `{"spellcheck":{
+			"suggestions":{
+				"delll":{"numFound":1,"startOffset":0,"endOffset":5,"origFreq":0,"suggestion":[{"word":"dell","freq":2}]},
+				"ultrashar":{"numFound":1,"startOffset":6,"endOffset":15,"origFreq":0,"suggestion":[{"word":"ultrasharp","freq":1}]}
+			},
+			"correctlySpelled":false,
+			"collations":{
+				"collation":{"collationQuery":"dell ultrasharp","hits":1,"misspellingsAndCorrections":["delll","dell","ultrashar","ultrasharp"]},
+				"collation":{"collationQuery":"dell ultra","hits":0,"misspellingsAndCorrections":{"delll":"dell","ultrashar":"ultra"}}
+			}
+		}}`
+
+		var resp solr.QueryResponse
+		err := json.Unmarshal([]byte(body), &resp)
+		require.NoError(t, err)
+
+		sc := resp.Spellcheck
+		require.Len(t, sc.Suggestions, 2)
+		assert.Equal(t, "ultrashar", sc.Suggestions[1].Term)
+		assert.Equal(t, []solr.SpellcheckWord{{Word: "ultrasharp", Freq: 1}}, sc.Suggestions[1].Suggestions)
+
+		expect := []solr.SpellcheckCollation{
+			{
+				CollationQuery: "dell ultrasharp",
+				Hits:           1,
+				MisspellingsAndCorrections: map[string]string{
+					"delll":     "dell",
+					"ultrashar": "ultrasharp",
+				},
+			},
+			{
+				CollationQuery: "dell ultra",
+				MisspellingsAndCorrections: map[string]string{
+					"delll":     "dell",
+					"ultrashar": "ultra",
+				},
+			},
+		}
+		assert.Equal(t, expect, sc.Collations)
+		assert.Equal(t, "dell ultrasharp", sc.BestCollation())
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		var resp solr.QueryResponse
+		err := json.Unmarshal([]byte(`{"spellcheck":{"suggestions":["delll"]}}`), &resp)
+		assert.Error(t, err)
+
+		err = json.Unmarshal([]byte(`{"spellcheck":{"collations":["collation",1]}}`), &resp)
+		assert.Error(t, err)
+	})
+
+	var nilResult *solr.SpellcheckResult
+	assert.Equal(t, "", nilResult.BestCollation())
+}
@@ -0,0 +1,57 @@
+package solr
+
+import (
+	"net/url"
+	"strconv"
+)
+
+// SQLOptions is the parallel SQL interface param builder
+//
+// Refer to https://solr.apache.org/guide/8_8/parallel-sql-interface.html
+type SQLOptions struct {
+	aggregationMode  string
+	numWorkers       int
+	workerCollection string
+}
+
+// NewSQLOptions returns a new SQLOptions
+func NewSQLOptions() *SQLOptions {
+	return &SQLOptions{}
+}
+
+// AggregationMode sets the aggregation mode (facet or map_reduce)
+func (o *SQLOptions) AggregationMode(aggregationMode string) *SQLOptions {
+	o.aggregationMode = aggregationMode
+	return o
+}
+
+// NumWorkers sets the number of workers, only used with map_reduce
+func (o *SQLOptions) NumWorkers(numWorkers int) *SQLOptions {
+	o.numWorkers = numWorkers
+	return o
+}
+
+// WorkerCollection sets the collection of the workers, only used with map_reduce
+func (o *SQLOptions) WorkerCollection(workerCollection string) *SQLOptions {
+	o.workerCollection = workerCollection
+	return o
+}
+
+// BuildParams builds the parameters
+func (o *SQLOptions) BuildParams() string {
+	vals := &url.Values{}
+
+	if o.aggregationMode != "" {
+		vals.Add("aggregationMode", o.aggregationMode)
+	}
+
+	if o.numWorkers > 0 {
+		vals.Add("numWorkers", strconv.Itoa(o.numWorkers))
+	}
+
+	if o.workerCollection != "" {
+		vals.Add("workerCollection", o.workerCollection)
+	}
+
+	return vals.Encode()
+}
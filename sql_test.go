@@ -0,0 +1,21 @@
+package solr_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/stevenferrer/solr-go"
+)
+
+func TestSQLOptions(t *testing.T) {
+	got := solr.NewSQLOptions().BuildParams()
+	assert.Equal(t, "", got)
+
+	got = solr.NewSQLOptions().
+		AggregationMode("map_reduce").
+		NumWorkers(2).
+		WorkerCollection("workers").
+		BuildParams()
+	assert.Equal(t, "aggregationMode=map_reduce&numWorkers=2&workerCollection=workers", got)
+}
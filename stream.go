@@ -0,0 +1,446 @@
+package solr
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// StreamExpr is a streaming expression builder. The constructors of
+// the common sources and decorators take their required arguments,
+// other arguments can be added with Param, Arg and Stream.
+//
+// Refer to https://solr.apache.org/guide/8_8/streaming-expressions.html
+type StreamExpr struct {
+	name string
+	args []string
+}
+
+// NewStreamExpr returns a new StreamExpr for the function name,
+// which can be used for functions that don't have a dedicated constructor
+func NewStreamExpr(name string) *StreamExpr {
+	return &StreamExpr{name: name}
+}
+
+// Arg adds a positional argument as is e.g. a collection or a metric
+func (e *StreamExpr) Arg(arg string) *StreamExpr {
+	e.args = append(e.args, arg)
+	return e
+}
+
+// Stream adds a nested stream as a positional argument
+func (e *StreamExpr) Stream(stream *StreamExpr) *StreamExpr {
+	return e.Arg(stream.String())
+}
+
+// Param adds a named parameter with a quoted value e.g. q="*:*"
+func (e *StreamExpr) Param(key, value string) *StreamExpr {
+	return e.Arg(fmt.Sprintf("%s=%s", key, quoteStreamValue(value)))
+}
+
+// NamedStream adds a nested stream as a named parameter e.g. hashed=search(...)
+func (e *StreamExpr) NamedStream(key string, stream *StreamExpr) *StreamExpr {
+	return e.Arg(fmt.Sprintf("%s=%s", key, stream))
+}
+
+// String implements Stringer, it builds the expression
+func (e *StreamExpr) String() string {
+	return fmt.Sprintf("%s(%s)", e.name, strings.Join(e.args, ", "))
+}
+
+// quoteStreamValue quotes the parameter value, escaping backslashes and quotes
+func quoteStreamValue(value string) string {
+	return `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(value) + `"`
+}
+
+// SearchExpr returns the search source, use Param to add other parameters
+// e.g. Param("qt", "/export") to stream all matching documents
+//
+// Refer to https://solr.apache.org/guide/8_8/stream-source-reference.html#search
+func SearchExpr(collection, q, fl, sort string) *StreamExpr {
+	return NewStreamExpr("search").Arg(collection).
+		Param("q", q).Param("fl", fl).Param("sort", sort)
+}
+
+// FacetExpr returns the facet source, which aggregates the metrics
+// e.g. sum(price), count(*) over the buckets
+//
+// Refer to https://solr.apache.org/guide/8_8/stream-source-reference.html#facet
+func FacetExpr(collection, q, buckets, bucketSorts string, bucketSizeLimit int, metrics ...string) *StreamExpr {
+	e := NewStreamExpr("facet").Arg(collection).
+		Param("q", q).Param("buckets", buckets).Param("bucketSorts", bucketSorts).
+		Arg(fmt.Sprintf("bucketSizeLimit=%d", bucketSizeLimit))
+	for _, metric := range metrics {
+		e.Arg(metric)
+	}
+
+	return e
+}
+
+// StatsExpr returns the stats source, which computes the metrics of the matching documents
+//
+// Refer to https://solr.apache.org/guide/8_8/stream-source-reference.html#stats
+func StatsExpr(collection, q string, metrics ...string) *StreamExpr {
+	e := NewStreamExpr("stats").Arg(collection).Param("q", q)
+	for _, metric := range metrics {
+		e.Arg(metric)
+	}
+
+	return e
+}
+
+// RollupExpr returns the rollup decorator, which groups the tuples of the
+// stream, sorted by the over fields, and computes the metrics of each group
+//
+// Refer to https://solr.apache.org/guide/8_8/stream-decorator-reference.html#rollup
+func RollupExpr(stream *StreamExpr, over string, metrics ...string) *StreamExpr {
+	e := NewStreamExpr("rollup").Stream(stream).Param("over", over)
+	for _, metric := range metrics {
+		e.Arg(metric)
+	}
+
+	return e
+}
+
+// TopExpr returns the top decorator, which returns the top n tuples of the stream
+//
+// Refer to https://solr.apache.org/guide/8_8/stream-decorator-reference.html#top
+func TopExpr(n int, stream *StreamExpr, sort string) *StreamExpr {
+	return NewStreamExpr("top").Arg("n="+strconv.Itoa(n)).
+		Stream(stream).Param("sort", sort)
+}
+
+// UniqueExpr returns the unique decorator, which removes the tuples
+// of the stream, sorted by the over fields, with duplicate values
+//
+// Refer to https://solr.apache.org/guide/8_8/stream-decorator-reference.html#unique
+func UniqueExpr(stream *StreamExpr, over string) *StreamExpr {
+	return NewStreamExpr("unique").Stream(stream).Param("over", over)
+}
+
+// InnerJoinExpr returns the innerJoin decorator, which joins the
+// left and right streams, both sorted by the on fields
+//
+// Refer to https://solr.apache.org/guide/8_8/stream-decorator-reference.html#innerjoin
+func InnerJoinExpr(left, right *StreamExpr, on string) *StreamExpr {
+	return NewStreamExpr("innerJoin").Stream(left).Stream(right).Param("on", on)
+}
+
+// HashJoinExpr returns the hashJoin decorator, which joins the stream
+// with the hashed stream, which is read into memory
+//
+// Refer to https://solr.apache.org/guide/8_8/stream-decorator-reference.html#hashjoin
+func HashJoinExpr(stream, hashed *StreamExpr, on string) *StreamExpr {
+	return NewStreamExpr("hashJoin").Stream(stream).
+		NamedStream("hashed", hashed).Param("on", on)
+}
+
+// MergeExpr returns the merge decorator, which merges the streams,
+// all sorted by the on fields
+//
+// Refer to https://solr.apache.org/guide/8_8/stream-decorator-reference.html#merge
+func MergeExpr(on string, streams ...*StreamExpr) *StreamExpr {
+	e := NewStreamExpr("merge")
+	for _, stream := range streams {
+		e.Stream(stream)
+	}
+
+	return e.Param("on", on)
+}
+
+// UpdateExpr returns the update decorator, which indexes
+// the tuples of the stream into the collection
+//
+// Refer to https://solr.apache.org/guide/8_8/stream-decorator-reference.html#update
+func UpdateExpr(collection string, batchSize int, stream *StreamExpr) *StreamExpr {
+	return NewStreamExpr("update").Arg(collection).
+		Arg("batchSize=" + strconv.Itoa(batchSize)).Stream(stream)
+}
+
+// CommitExpr returns the commit decorator, which commits the collection
+// after the tuples of the (update) stream are indexed
+//
+// Refer to https://solr.apache.org/guide/8_8/stream-decorator-reference.html#commit
+func CommitExpr(collection string, stream *StreamExpr) *StreamExpr {
+	return NewStreamExpr("commit").Arg(collection).Stream(stream)
+}
+
+// DaemonExpr returns the daemon decorator, which runs the stream
+// in the background every runInterval milliseconds
+//
+// Refer to https://solr.apache.org/guide/8_8/stream-decorator-reference.html#daemon
+func DaemonExpr(stream *StreamExpr, id string, runInterval int, terminate bool) *StreamExpr {
+	return NewStreamExpr("daemon").Stream(stream).Param("id", id).
+		Param("runInterval", strconv.Itoa(runInterval)).
+		Param("terminate", strconv.FormatBool(terminate))
+}
+
+// StreamError is the error of an EXCEPTION tuple
+type StreamError struct {
+	Msg string
+}
+
+func (e StreamError) Error() string {
+	return e.Msg
+}
+
+// TupleStream is an iterator over the tuples (or documents) of a streaming
+// response, which decodes one tuple at a time. The stream stops at the EOF tuple, or
+// at the first EXCEPTION tuple, which is returned by Err. If the response ends
+// early e.g. the connection is dropped, Err returns io.ErrUnexpectedEOF.
+//
+//	for stream.Next() {
+//		tuple := stream.Tuple()
+//		...
+//	}
+//	if err := stream.Err(); err != nil {
+//		...
+//	}
+type TupleStream struct {
+	body   io.ReadCloser
+	reader *eofReader
+	dec    *json.Decoder
+	tuple  M
+	fields []string
+	err    error
+	done   bool
+	// depth is the number of objects enclosing the list
+	depth int
+	// eofTuple is true if the list ends with the EOF tuple
+	eofTuple bool
+}
+
+// newTupleStream returns a new TupleStream which decodes the
+// tuples of the list located at the path of the json body
+// e.g. result-set > docs. If eofTuple is true, the list must
+// end with the EOF tuple, otherwise the response is truncated.
+func newTupleStream(body io.ReadCloser, eofTuple bool, path ...string) (*TupleStream, error) {
+	reader := &eofReader{r: body}
+	dec := json.NewDecoder(reader)
+	dec.UseNumber()
+	for _, key := range path {
+		err := seekKey(dec, key)
+		if err != nil {
+			body.Close()
+			return nil, wrapErr(err, "seek "+key)
+		}
+	}
+
+	tok, err := dec.Token()
+	if err != nil {
+		body.Close()
+		return nil, wrapErr(err, "read tuples")
+	}
+
+	if delim, ok := tok.(json.Delim); !ok || delim != '[' {
+		body.Close()
+		return nil, fmt.Errorf("expecting a list of tuples but got %v", tok)
+	}
+
+	return &TupleStream{body: body, reader: reader, dec: dec,
+		depth: len(path), eofTuple: eofTuple}, nil
+}
+
+// seekKey moves the decoder to the value of the key
+// of the next object, skipping the other keys
+func seekKey(dec *json.Decoder, key string) error {
+	tok, err := dec.Token()
+	if err != nil {
+		return err
+	}
+
+	if delim, ok := tok.(json.Delim); !ok || delim != '{' {
+		return fmt.Errorf("expecting an object but got %v", tok)
+	}
+
+	for dec.More() {
+		tok, err = dec.Token()
+		if err != nil {
+			return err
+		}
+
+		if tok == key {
+			return nil
+		}
+
+		var skip json.RawMessage
+		err = dec.Decode(&skip)
+		if err != nil {
+			return err
+		}
+	}
+
+	return fmt.Errorf("key %q not found", key)
+}
+
+// Next decodes the next tuple, it returns false when the
+// stream reaches the end or an error is encountered
+func (s *TupleStream) Next() bool {
+	if s.done {
+		return false
+	}
+
+	if !s.dec.More() {
+		err := s.readEnd()
+		if err == nil && s.eofTuple {
+			err = io.ErrUnexpectedEOF
+		}
+		s.finish(err)
+		return false
+	}
+
+	var tuple M
+	err := s.dec.Decode(&tuple)
+	if err != nil {
+		s.finish(s.unexpectedEOF(wrapErr(err, "decode tuple")))
+		return false
+	}
+
+	if exception, ok := tuple["EXCEPTION"]; ok {
+		s.finish(&StreamError{Msg: fmt.Sprint(exception)})
+		return false
+	}
+
+	if eof, _ := tuple["EOF"].(bool); eof {
+		s.finish(s.readEnd())
+		return false
+	}
+
+	if isMetadata, _ := tuple["isMetadata"].(bool); isMetadata {
+		s.fields = toStrings(tuple["fields"])
+		return s.Next()
+	}
+
+	s.tuple = tuple
+	return true
+}
+
+// readEnd reads the end of the list and of the enclosing objects,
+// skipping the keys after the list. It returns io.ErrUnexpectedEOF
+// if the body ends before, e.g. the response is truncated.
+func (s *TupleStream) readEnd() error {
+	err := s.expectEnd(']')
+	if err != nil {
+		return err
+	}
+
+	for i := 0; i < s.depth; i++ {
+		for s.dec.More() {
+			_, err = s.dec.Token()
+			if err == nil {
+				var skip json.RawMessage
+				err = s.dec.Decode(&skip)
+			}
+
+			if err != nil {
+				return s.unexpectedEOF(wrapErr(err, "read end of response"))
+			}
+		}
+
+		err = s.expectEnd('}')
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// expectEnd reads the closing delimiter
+func (s *TupleStream) expectEnd(delim json.Delim) error {
+	tok, err := s.dec.Token()
+	if err != nil {
+		return s.unexpectedEOF(wrapErr(err, "read end of response"))
+	}
+
+	if tok != delim {
+		return fmt.Errorf("expecting %v but got %v", delim, tok)
+	}
+
+	return nil
+}
+
+// unexpectedEOF wraps io.ErrUnexpectedEOF if the error is caused by the
+// end of the body. The decoder returns io.ErrUnexpectedEOF if the body ends
+// within a value, but a *json.SyntaxError at the end of the input if it
+// ends between values, which is told apart from a syntax error by its offset.
+func (s *TupleStream) unexpectedEOF(err error) error {
+	if errors.Is(err, io.ErrUnexpectedEOF) {
+		return err
+	}
+
+	var syntaxErr *json.SyntaxError
+	if errors.Is(err, io.EOF) || (errors.As(err, &syntaxErr) &&
+		s.reader.eof && syntaxErr.Offset >= s.reader.n) {
+		return fmt.Errorf("%w: %s", io.ErrUnexpectedEOF, err)
+	}
+
+	return err
+}
+
+// eofReader records the number of bytes read and whether
+// the end of the reader is reached
+type eofReader struct {
+	r   io.Reader
+	n   int64
+	eof bool
+}
+
+// Read implements io.Reader
+func (r *eofReader) Read(p []byte) (int, error) {
+	n, err := r.r.Read(p)
+	r.n += int64(n)
+	if err == io.EOF {
+		r.eof = true
+	}
+
+	return n, err
+}
+
+// Tuple returns the current tuple, numbers are decoded as json.Number
+// so that longs are not truncated
+func (s *TupleStream) Tuple() M {
+	return s.tuple
+}
+
+// Fields returns the fields of the tuples in order, which are only available
+// if the response includes the metadata tuple e.g. for SQL responses.
+func (s *TupleStream) Fields() []string {
+	return s.fields
+}
+
+// Err returns the error, if any, encountered during the iteration
+func (s *TupleStream) Err() error {
+	return s.err
+}
+
+// Close closes the stream, it must be called if the
+// stream is not iterated until Next returns false
+func (s *TupleStream) Close() error {
+	if s.done {
+		return nil
+	}
+
+	s.done = true
+	s.tuple = nil
+	return s.body.Close()
+}
+
+func (s *TupleStream) finish(err error) {
+	s.err = err
+	s.Close()
+}
+
+// toStrings converts a decoded json list to a list of strings
+func toStrings(v interface{}) []string {
+	list, _ := v.([]interface{})
+	strs := make([]string, 0, len(list))
+	for _, item := range list {
+		strs = append(strs, fmt.Sprint(item))
+	}
+
+	return strs
+}
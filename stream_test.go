@@ -0,0 +1,96 @@
+package solr_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/stevenferrer/solr-go"
+)
+
+func TestStreamExpr(t *testing.T) {
+	search := solr.SearchExpr("techproducts", "*:*", "id,price", "id asc")
+
+	var tests = []struct {
+		name   string
+		expr   *solr.StreamExpr
+		expect string
+	}{
+		{
+			"search",
+			search,
+			`search(techproducts, q="*:*", fl="id,price", sort="id asc")`,
+		},
+		{
+			"search with params",
+			solr.SearchExpr("techproducts", `name:"ipod"`, "id", "id asc").Param("qt", "/export"),
+			`search(techproducts, q="name:\"ipod\"", fl="id", sort="id asc", qt="/export")`,
+		},
+		{
+			"search with backslashes",
+			solr.SearchExpr("techproducts", `path:C\\dir\\`, "id", "id asc"),
+			`search(techproducts, q="path:C\\\\dir\\\\", fl="id", sort="id asc")`,
+		},
+		{
+			"facet",
+			solr.FacetExpr("techproducts", "*:*", "cat", "sum(price) desc", 10, "sum(price)", "count(*)"),
+			`facet(techproducts, q="*:*", buckets="cat", bucketSorts="sum(price) desc", bucketSizeLimit=10, sum(price), count(*))`,
+		},
+		{
+			"stats",
+			solr.StatsExpr("techproducts", "*:*", "min(price)", "max(price)"),
+			`stats(techproducts, q="*:*", min(price), max(price))`,
+		},
+		{
+			"rollup",
+			solr.RollupExpr(search, "cat", "sum(price)"),
+			`rollup(search(techproducts, q="*:*", fl="id,price", sort="id asc"), over="cat", sum(price))`,
+		},
+		{
+			"top",
+			solr.TopExpr(3, search, "price desc"),
+			`top(n=3, search(techproducts, q="*:*", fl="id,price", sort="id asc"), sort="price desc")`,
+		},
+		{
+			"unique",
+			solr.UniqueExpr(search, "id"),
+			`unique(search(techproducts, q="*:*", fl="id,price", sort="id asc"), over="id")`,
+		},
+		{
+			"inner join",
+			solr.InnerJoinExpr(search, solr.SearchExpr("people", "*:*", "id,name", "id asc"), "id"),
+			`innerJoin(search(techproducts, q="*:*", fl="id,price", sort="id asc"), search(people, q="*:*", fl="id,name", sort="id asc"), on="id")`,
+		},
+		{
+			"hash join",
+			solr.HashJoinExpr(search, solr.SearchExpr("people", "*:*", "id,name", "id asc"), "id=personId"),
+			`hashJoin(search(techproducts, q="*:*", fl="id,price", sort="id asc"), hashed=search(people, q="*:*", fl="id,name", sort="id asc"), on="id=personId")`,
+		},
+		{
+			"merge",
+			solr.MergeExpr("id asc", search, solr.SearchExpr("products", "*:*", "id,price", "id asc")),
+			`merge(search(techproducts, q="*:*", fl="id,price", sort="id asc"), search(products, q="*:*", fl="id,price", sort="id asc"), on="id asc")`,
+		},
+		{
+			"update and commit",
+			solr.CommitExpr("destination", solr.UpdateExpr("destination", 250, search)),
+			`commit(destination, update(destination, batchSize=250, search(techproducts, q="*:*", fl="id,price", sort="id asc")))`,
+		},
+		{
+			"daemon",
+			solr.DaemonExpr(solr.UpdateExpr("destination", 100, search), "daemon1", 1000, true),
+			`daemon(update(destination, batchSize=100, search(techproducts, q="*:*", fl="id,price", sort="id asc")), id="daemon1", runInterval="1000", terminate="true")`,
+		},
+		{
+			"custom",
+			solr.NewStreamExpr("sort").Stream(search).Param("by", "price desc"),
+			`sort(search(techproducts, q="*:*", fl="id,price", sort="id asc"), by="price desc")`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.expect, test.expr.String())
+		})
+	}
+}
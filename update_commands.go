@@ -0,0 +1,97 @@
+package solr
+
+import (
+	"bytes"
+	"encoding/json"
+)
+
+// List of special _version_ constraints
+//
+// Refer to https://solr.apache.org/guide/8_8/updating-parts-of-documents.html#optimistic-concurrency
+const (
+	// VersionMustExist requires the document to exist
+	VersionMustExist int64 = 1
+	// VersionMustNotExist requires the document to not exist
+	VersionMustNotExist int64 = -1
+)
+
+// UpdateCommands is a builder for JSON update commands
+//
+// Refer to https://solr.apache.org/guide/8_8/uploading-data-with-index-handlers.html#sending-json-update-commands
+type UpdateCommands struct {
+	commands []updateCommand
+}
+
+// updateCommand is a single update command
+type updateCommand struct {
+	name string
+	body interface{}
+}
+
+var _ json.Marshaler = (*UpdateCommands)(nil)
+
+// NewUpdateCommands returns a new UpdateCommands
+func NewUpdateCommands() *UpdateCommands {
+	return &UpdateCommands{}
+}
+
+// Add adds an add command for the document
+func (uc *UpdateCommands) Add(doc interface{}) *UpdateCommands {
+	return uc.addCommand("add", M{"doc": doc})
+}
+
+// AddWithVersion adds an add command for the document
+// that only succeeds if the _version_ constraint is satisfied
+func (uc *UpdateCommands) AddWithVersion(doc M, version int64) *UpdateCommands {
+	versioned := M{}
+	for k, v := range doc {
+		versioned[k] = v
+	}
+	versioned["_version_"] = version
+
+	return uc.Add(versioned)
+}
+
+// Delete adds a delete command for the documents with the specified ids
+func (uc *UpdateCommands) Delete(ids ...string) *UpdateCommands {
+	return uc.addCommand("delete", ids)
+}
+
+// DeleteWithVersion adds a delete command for the document
+// that only succeeds if the _version_ constraint is satisfied
+func (uc *UpdateCommands) DeleteWithVersion(id string, version int64) *UpdateCommands {
+	return uc.addCommand("delete", M{"id": id, "_version_": version})
+}
+
+// DeleteByQuery adds a delete command for the documents matching the query
+func (uc *UpdateCommands) DeleteByQuery(query string) *UpdateCommands {
+	return uc.addCommand("delete", M{"query": query})
+}
+
+func (uc *UpdateCommands) addCommand(name string, body interface{}) *UpdateCommands {
+	uc.commands = append(uc.commands, updateCommand{name: name, body: body})
+	return uc
+}
+
+// MarshalJSON implements json.Marshaler. The commands are written in
+// order and may repeat the same key, which Solr allows for update commands.
+func (uc *UpdateCommands) MarshalJSON() ([]byte, error) {
+	buf := &bytes.Buffer{}
+	buf.WriteByte('{')
+	for i, command := range uc.commands {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+
+		b, err := json.Marshal(command.body)
+		if err != nil {
+			return nil, wrapErr(err, "marshal "+command.name+" command")
+		}
+
+		buf.WriteString(`"` + command.name + `":`)
+		buf.Write(b)
+	}
+	buf.WriteByte('}')
+
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,37 @@
+package solr_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/stevenferrer/solr-go"
+)
+
+func TestUpdateCommands(t *testing.T) {
+	commands := solr.NewUpdateCommands().
+		Add(solr.M{"id": "1", "name": "product 1"}).
+		AddWithVersion(solr.M{"id": "2", "name": "product 2"}, 1632740120218042368).
+		Delete("3", "4").
+		DeleteWithVersion("5", solr.VersionMustExist).
+		DeleteByQuery("name:foo")
+
+	b, err := json.Marshal(commands)
+	require.NoError(t, err)
+
+	expect := `{"add":{"doc":{"id":"1","name":"product 1"}},` +
+		`"add":{"doc":{"_version_":1632740120218042368,"id":"2","name":"product 2"}},` +
+		`"delete":["3","4"],` +
+		`"delete":{"_version_":1,"id":"5"},` +
+		`"delete":{"query":"name:foo"}}`
+	assert.Equal(t, expect, string(b))
+
+	b, err = json.Marshal(solr.NewUpdateCommands())
+	require.NoError(t, err)
+	assert.Equal(t, "{}", string(b))
+
+	_, err = json.Marshal(solr.NewUpdateCommands().Add(make(chan int)))
+	assert.Error(t, err)
+}
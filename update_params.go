@@ -0,0 +1,53 @@
+package solr
+
+import (
+	"net/url"
+	"strconv"
+)
+
+// UpdateParams is the update API param builder
+type UpdateParams struct {
+	// versions if true, the assigned document versions are returned
+	versions bool
+	// commitWithin is the number of milliseconds
+	// within which the update will be committed
+	commitWithin int
+}
+
+// NewUpdateParams returns a new UpdateParams
+func NewUpdateParams() *UpdateParams {
+	return &UpdateParams{}
+}
+
+// Versions if true, the response will include the versions
+// assigned to the added or deleted documents.
+//
+// Refer to https://solr.apache.org/guide/8_8/updating-parts-of-documents.html#optimistic-concurrency
+func (p *UpdateParams) Versions(versions bool) *UpdateParams {
+	p.versions = versions
+	return p
+}
+
+// CommitWithin sets the number of milliseconds within which
+// the documents in the update will be committed.
+//
+// Refer to https://solr.apache.org/guide/8_8/uploading-data-with-index-handlers.html
+func (p *UpdateParams) CommitWithin(ms int) *UpdateParams {
+	p.commitWithin = ms
+	return p
+}
+
+// BuildParams builds the parameters
+func (p *UpdateParams) BuildParams() string {
+	vals := &url.Values{}
+
+	if p.versions {
+		vals.Add("versions", "true")
+	}
+
+	if p.commitWithin > 0 {
+		vals.Add("commitWithin", strconv.Itoa(p.commitWithin))
+	}
+
+	return vals.Encode()
+}
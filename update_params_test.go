@@ -0,0 +1,20 @@
+package solr_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/stevenferrer/solr-go"
+)
+
+func TestBuildUpdateParams(t *testing.T) {
+	got := solr.NewUpdateParams().BuildParams()
+	assert.Equal(t, "", got)
+
+	got = solr.NewUpdateParams().Versions(true).BuildParams()
+	assert.Equal(t, "versions=true", got)
+
+	got = solr.NewUpdateParams().Versions(true).CommitWithin(1000).BuildParams()
+	assert.Equal(t, "commitWithin=1000&versions=true", got)
+}
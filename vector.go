@@ -0,0 +1,62 @@
+package solr
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Similarity functions of the DenseVectorField
+const (
+	Euclidean  = "euclidean"
+	DotProduct = "dot_product"
+	Cosine     = "cosine"
+)
+
+// Vector is a dense vector of a DenseVectorField. It is encoded as a
+// list of numbers when indexing, and its String is used in knn queries.
+//
+// Refer to https://solr.apache.org/guide/solr/latest/query-guide/dense-vector-search.html
+type Vector []float32
+
+// String implements Stringer e.g. [1.0,2.5] is "[1,2.5]"
+func (v Vector) String() string {
+	values := make([]string, 0, len(v))
+	for _, f := range v {
+		values = append(values, strconv.FormatFloat(float64(f), 'f', -1, 32))
+	}
+
+	return "[" + strings.Join(values, ",") + "]"
+}
+
+// ToVector converts the decoded value of a vector field of a document
+// e.g. ToVector(doc["vector"])
+func ToVector(v interface{}) (Vector, error) {
+	switch val := v.(type) {
+	case Vector:
+		return val, nil
+	case []float32:
+		return val, nil
+	case []interface{}:
+		vec := make(Vector, 0, len(val))
+		for _, item := range val {
+			switch num := item.(type) {
+			case float64:
+				vec = append(vec, float32(num))
+			case json.Number:
+				f, err := num.Float64()
+				if err != nil {
+					return nil, wrapErr(err, "parse vector value")
+				}
+				vec = append(vec, float32(f))
+			default:
+				return nil, fmt.Errorf("expecting vector values to be numbers but got %T", item)
+			}
+		}
+
+		return vec, nil
+	}
+
+	return nil, fmt.Errorf("expecting a list of numbers but got %T", v)
+}
@@ -0,0 +1,71 @@
+package solr_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/stevenferrer/solr-go"
+)
+
+func TestVector(t *testing.T) {
+	vec := solr.Vector{1, 2.5, -0.1}
+	assert.Equal(t, "[1,2.5,-0.1]", vec.String())
+
+	b, err := json.Marshal(solr.M{"id": "1", "vector": vec})
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"id":"1","vector":[1,2.5,-0.1]}`, string(b))
+
+	var doc solr.M
+	err = json.Unmarshal(b, &doc)
+	require.NoError(t, err)
+
+	got, err := solr.ToVector(doc["vector"])
+	require.NoError(t, err)
+	assert.Equal(t, vec, got)
+
+	got, err = solr.ToVector([]float32{1, 2})
+	require.NoError(t, err)
+	assert.Equal(t, solr.Vector{1, 2}, got)
+
+	_, err = solr.ToVector([]interface{}{"a"})
+	assert.Error(t, err)
+
+	_, err = solr.ToVector("a")
+	assert.Error(t, err)
+
+	_, err = solr.ToVector([]interface{}{json.Number("x")})
+	assert.Error(t, err)
+
+	t.Run("real-time get", func(t *testing.T) {
+		var resp solr.GetResponse
+		err := json.Unmarshal([]byte(`{"doc":{"id":"1","vector":[1,2.5,-0.1],"_version_":1632740120218042368}}`), &resp)
+		require.NoError(t, err)
+		require.Len(t, resp.Documents, 1)
+
+		got, err := solr.ToVector(resp.Documents[0]["vector"])
+		require.NoError(t, err)
+		assert.Equal(t, vec, got)
+	})
+}
+
+func TestDenseVectorFieldType(t *testing.T) {
+	fieldType := solr.FieldType{
+		Name:               "knn_vector",
+		Class:              "solr.DenseVectorField",
+		VectorDimension:    4,
+		SimilarityFunction: solr.Cosine,
+		KnnAlgorithm:       "hnsw",
+		HnswMaxConnections: 10,
+		HnswBeamWidth:      40,
+	}
+
+	b, err := json.Marshal(fieldType)
+	require.NoError(t, err)
+
+	expect := `{"name":"knn_vector","class":"solr.DenseVectorField","vectorDimension":4,
+		"similarityFunction":"cosine","knnAlgorithm":"hnsw","hnswMaxConnections":10,"hnswBeamWidth":40}`
+	assert.JSONEq(t, expect, string(b))
+}